@@ -0,0 +1,191 @@
+package rsmap
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	connect_go "github.com/bufbuild/connect-go"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/poll"
+
+	logsv1 "github.com/daichitakahashi/rsmap/internal/proto/logs/v1"
+	resource_mapv1 "github.com/daichitakahashi/rsmap/internal/proto/resource_map/v1"
+	"github.com/daichitakahashi/rsmap/internal/proto/resource_map/v1/resource_mapv1connect"
+	"github.com/daichitakahashi/rsmap/logs"
+)
+
+func TestAdminOperations(t *testing.T) {
+	t.Parallel()
+
+	launch := func(t *testing.T) (*Map, *serverSideMap, resource_mapv1connect.ResourceMapServiceClient) {
+		t.Helper()
+
+		dir := t.TempDir()
+		m, err := New(dir, WithExecutionID("admin"))
+		assert.NilError(t, err)
+		t.Cleanup(m.Close)
+
+		var (
+			addr string
+			s    *serverSideMap
+		)
+		poll.WaitOn(t, func(t poll.LogT) poll.Result {
+			data, err := os.ReadFile(filepath.Join(dir, "admin", "addr"))
+			if err != nil {
+				return poll.Continue("server is not launched yet: %s", err)
+			}
+			addr = string(data)
+
+			var ok bool
+			m._mu.Lock()
+			s, ok = m._rm.(*serverSideMap)
+			m._mu.Unlock()
+			if !ok {
+				return poll.Continue("resourceMap is not replaced yet")
+			}
+			return poll.Success()
+		})
+		return m, s, resource_mapv1connect.NewResourceMapServiceClient(http.DefaultClient, addr)
+	}
+
+	t.Run("ForceRelease releases the lock of the holder", func(t *testing.T) {
+		t.Parallel()
+
+		m, s, cli := launch(t)
+		r1, err := m.Resource(background, "treasure")
+		assert.NilError(t, err)
+		r2, err := m.Resource(background, "treasure")
+		assert.NilError(t, err)
+		assert.NilError(t, r1.Lock(background))
+
+		ctx, cancel := context.WithCancel(background)
+		defer cancel()
+		locked := asyncResult(func() error {
+			return r2.Lock(ctx)
+		})
+
+		// Unknown resource and holder.
+		_, err = cli.ForceRelease(background, connect_go.NewRequest(&resource_mapv1.ForceReleaseRequest{
+			ResourceName: "unknown",
+			CallerHash:   "unknown",
+		}))
+		assert.Equal(t, connect_go.CodeOf(err), connect_go.CodeNotFound)
+		_, err = cli.ForceRelease(background, connect_go.NewRequest(&resource_mapv1.ForceReleaseRequest{
+			ResourceName: "treasure",
+			CallerHash:   "unknown",
+		}))
+		assert.Equal(t, connect_go.CodeOf(err), connect_go.CodeNotFound)
+
+		hash := r1._operator.Context[len(r1._operator.Context)-1].Hash
+		resp, err := cli.ForceRelease(background, connect_go.NewRequest(&resource_mapv1.ForceReleaseRequest{
+			ResourceName: "treasure",
+			CallerHash:   hash,
+		}))
+		assert.NilError(t, err)
+		assert.Equal(t, logs.CallerContext(resp.Msg.Context).String(), r1._operator.Context.String())
+
+		// r2 acquires the lock released forcibly.
+		assert.NilError(t, <-locked)
+		// Release by r1 after that is ignored.
+		assert.NilError(t, r1.UnlockAny())
+		assert.NilError(t, r2.UnlockAny())
+
+		// Force release is recorded as an admin operation.
+		record, err := s._acquire._kv.Get("treasure")
+		assert.NilError(t, err)
+		var admin []*logsv1.AcquisitionLog
+		for _, l := range record.Logs {
+			if l.Admin {
+				admin = append(admin, l)
+			}
+		}
+		assert.Equal(t, len(admin), 1)
+		assert.Equal(t, admin[0].Event, logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED)
+	})
+
+	t.Run("ResetInit makes next try perform init again", func(t *testing.T) {
+		t.Parallel()
+
+		m, s, cli := launch(t)
+		var count int
+		init := WithInit(func(ctx context.Context) error {
+			count++
+			return nil
+		})
+		_, err := m.Resource(background, "treasure", init)
+		assert.NilError(t, err)
+		assert.Equal(t, count, 1)
+
+		_, err = cli.ResetInit(background, connect_go.NewRequest(&resource_mapv1.ResetInitRequest{
+			ResourceName: "unknown",
+		}))
+		assert.Equal(t, connect_go.CodeOf(err), connect_go.CodeNotFound)
+		_, err = cli.ResetInit(background, connect_go.NewRequest(&resource_mapv1.ResetInitRequest{
+			ResourceName: "treasure",
+		}))
+		assert.NilError(t, err)
+
+		_, err = m.Resource(background, "treasure", init)
+		assert.NilError(t, err)
+		assert.Equal(t, count, 2)
+
+		// Reset is recorded as an admin operation.
+		record, err := s._init._store.Get("treasure")
+		assert.NilError(t, err)
+		events := make([]logsv1.InitEvent, 0, len(record.Logs))
+		for _, l := range record.Logs {
+			events = append(events, l.Event)
+		}
+		assert.DeepEqual(t, events, []logsv1.InitEvent{
+			logsv1.InitEvent_INIT_EVENT_STARTED,
+			logsv1.InitEvent_INIT_EVENT_COMPLETED,
+			logsv1.InitEvent_INIT_EVENT_RESET,
+			logsv1.InitEvent_INIT_EVENT_STARTED,
+			logsv1.InitEvent_INIT_EVENT_COMPLETED,
+		})
+		assert.Assert(t, record.Logs[2].Admin)
+	})
+
+	t.Run("Server listens on the loopback address by default", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		m, err := New(dir, WithExecutionID("admin"))
+		assert.NilError(t, err)
+		t.Cleanup(m.Close)
+
+		poll.WaitOn(t, func(t poll.LogT) poll.Result {
+			data, err := os.ReadFile(filepath.Join(dir, "admin", "addr"))
+			if err != nil {
+				return poll.Continue("server is not launched yet: %s", err)
+			}
+			if !strings.HasPrefix(string(data), "http://127.0.0.1:") {
+				return poll.Error(fmt.Errorf("unexpected address: %s", data))
+			}
+			return poll.Success()
+		})
+	})
+}
+
+func TestCheckAdminPeer(t *testing.T) {
+	t.Parallel()
+
+	for _, addr := range []string{
+		"127.0.0.1:51234",
+		"[::1]:51234",
+	} {
+		assert.NilError(t, checkAdminPeer(addr), addr)
+	}
+	for _, addr := range []string{
+		"192.0.2.1:51234",
+		"[2001:db8::1]:51234",
+		"",
+	} {
+		assert.Equal(t, connect_go.CodeOf(checkAdminPeer(addr)), connect_go.CodePermissionDenied, addr)
+	}
+}
@@ -0,0 +1,232 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	connect_go "github.com/bufbuild/connect-go"
+	"github.com/spf13/pflag"
+
+	"github.com/daichitakahashi/rsmap"
+	resource_mapv1 "github.com/daichitakahashi/rsmap/internal/proto/resource_map/v1"
+	"github.com/daichitakahashi/rsmap/internal/proto/resource_map/v1/resource_mapv1connect"
+	"github.com/daichitakahashi/rsmap/logs"
+)
+
+const usage = `usage: rsmapctl [flags] <command> [args]
+
+commands:
+  status                                   show status of resources
+  holders <resource>                       show holders of the resource
+  force-release <resource> <caller-hash>   release the lock held by the caller forcibly
+  reset-init <resource>                    reset init status of the resource
+  list-executions                          list executions in the rsmap directory
+  gc                                       remove old executions in the rsmap directory
+`
+
+func Run() {
+	var (
+		rsmapDir    = pflag.StringP("dir", "d", ".rsmap", "")
+		executionID = pflag.StringP("execution-id", "e", os.Getenv(rsmap.EnvExecutionID), "")
+		timeout     = pflag.DurationP("timeout", "t", 5*time.Second, "")
+		maxAge      = pflag.DurationP("max-age", "a", 0, "")
+		maxCount    = pflag.IntP("max-count", "n", 0, "")
+	)
+	pflag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		pflag.PrintDefaults()
+	}
+	pflag.Parse()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	if err := run(ctx, *rsmapDir, *executionID, *maxAge, *maxCount, pflag.Args()); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(ctx context.Context, rsmapDir, executionID string, maxAge time.Duration, maxCount int, args []string) error {
+	if len(args) == 0 {
+		pflag.Usage()
+		return errors.New("command must be specified")
+	}
+	command, args := args[0], args[1:]
+	switch command {
+	case "list-executions":
+		return listExecutions(ctx, rsmapDir)
+	case "gc":
+		return gc(rsmapDir, maxAge, maxCount)
+	}
+
+	if executionID == "" {
+		return fmt.Errorf("execution ID must be specified by --execution-id or %s", rsmap.EnvExecutionID)
+	}
+	cli, err := newClient(rsmapDir, executionID)
+	if err != nil {
+		return err
+	}
+
+	switch command {
+	case "status":
+		return status(ctx, cli)
+	case "holders":
+		if len(args) != 1 {
+			return errors.New("usage: rsmapctl holders <resource>")
+		}
+		return holders(ctx, cli, args[0])
+	case "force-release":
+		if len(args) != 2 {
+			return errors.New("usage: rsmapctl force-release <resource> <caller-hash>")
+		}
+		return forceRelease(ctx, cli, args[0], args[1])
+	case "reset-init":
+		if len(args) != 1 {
+			return errors.New("usage: rsmapctl reset-init <resource>")
+		}
+		return resetInit(ctx, cli, args[0])
+	default:
+		return fmt.Errorf("unknown command: %s", command)
+	}
+}
+
+// Read the address of the server from `${rsmapDir}/${executionID}/addr`.
+func readAddr(rsmapDir, executionID string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(rsmapDir, executionID, "addr"))
+	if err != nil {
+		return "", fmt.Errorf("failed to read server address: %w", err)
+	}
+	return string(bytes.TrimSpace(data)), nil
+}
+
+func newClient(rsmapDir, executionID string) (resource_mapv1connect.ResourceMapServiceClient, error) {
+	addr, err := readAddr(rsmapDir, executionID)
+	if err != nil {
+		return nil, err
+	}
+	return resource_mapv1connect.NewResourceMapServiceClient(http.DefaultClient, addr), nil
+}
+
+func status(ctx context.Context, cli resource_mapv1connect.ResourceMapServiceClient) error {
+	resp, err := cli.GetStatus(ctx, connect_go.NewRequest(&resource_mapv1.GetStatusRequest{}))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Server %s, launched at %s\n\n", resp.Msg.Addr, time.Unix(0, resp.Msg.LaunchedAt).Format(time.DateTime))
+	tbl := newTable("Resource", "Max", "Holders", "Waiters", "Init")
+	for _, r := range resp.Msg.Resources {
+		tbl.AddRow(r.ResourceName, r.Max, len(r.Holders), len(r.Waiters), formatInitState(r.InitState))
+	}
+	tbl.Print()
+	return nil
+}
+
+func holders(ctx context.Context, cli resource_mapv1connect.ResourceMapServiceClient, resourceName string) error {
+	resp, err := cli.GetStatus(ctx, connect_go.NewRequest(&resource_mapv1.GetStatusRequest{}))
+	if err != nil {
+		return err
+	}
+
+	for _, r := range resp.Msg.Resources {
+		if r.ResourceName != resourceName {
+			continue
+		}
+		tbl := newTable("Hash", "N", "Context(Map->Resource)")
+		for _, h := range r.Holders {
+			c := logs.CallerContext(h.Context)
+			var hash string
+			if len(c) > 0 {
+				hash = c[len(c)-1].Hash
+			}
+			tbl.AddRow(hash, h.N, c.String())
+		}
+		tbl.Print()
+		return nil
+	}
+	return fmt.Errorf("resource not found: %s", resourceName)
+}
+
+func forceRelease(ctx context.Context, cli resource_mapv1connect.ResourceMapServiceClient, resourceName, callerHash string) error {
+	resp, err := cli.ForceRelease(ctx, connect_go.NewRequest(&resource_mapv1.ForceReleaseRequest{
+		ResourceName: resourceName,
+		CallerHash:   callerHash,
+	}))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("released %s held by %s\n", resourceName, logs.CallerContext(resp.Msg.Context))
+	return nil
+}
+
+func resetInit(ctx context.Context, cli resource_mapv1connect.ResourceMapServiceClient, resourceName string) error {
+	_, err := cli.ResetInit(ctx, connect_go.NewRequest(&resource_mapv1.ResetInitRequest{
+		ResourceName: resourceName,
+	}))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("reset init of %s\n", resourceName)
+	return nil
+}
+
+func listExecutions(ctx context.Context, rsmapDir string) error {
+	entries, err := os.ReadDir(rsmapDir)
+	if err != nil {
+		return err
+	}
+
+	tbl := newTable("Execution ID", "Address", "Server")
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		addr, err := readAddr(rsmapDir, e.Name())
+		if err != nil {
+			tbl.AddRow(e.Name(), "-", "-")
+			continue
+		}
+		server := "stopped"
+		if healthy(ctx, addr) {
+			server = "running"
+		}
+		tbl.AddRow(e.Name(), addr, server)
+	}
+	tbl.Print()
+	return nil
+}
+
+// Check whether the server is running.
+func healthy(ctx context.Context, addr string) bool {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, addr+"/healthz", nil)
+	if err != nil {
+		return false
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
+}
+
+func gc(rsmapDir string, maxAge time.Duration, maxCount int) error {
+	if maxAge <= 0 && maxCount <= 0 {
+		return errors.New("--max-age or --max-count must be specified")
+	}
+	removed, err := rsmap.GC(rsmapDir, maxAge, maxCount)
+	for _, dir := range removed {
+		fmt.Printf("removed %s\n", dir)
+	}
+	return err
+}
@@ -0,0 +1,33 @@
+package app
+
+import (
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+
+	resource_mapv1 "github.com/daichitakahashi/rsmap/internal/proto/resource_map/v1"
+)
+
+func newTable(columnHeaders ...interface{}) table.Table {
+	return table.New(columnHeaders...).
+		WithHeaderFormatter(
+			color.New(color.FgGreen, color.Underline).SprintfFunc(),
+		).
+		WithFirstColumnFormatter(
+			color.New(color.FgYellow).SprintfFunc(),
+		)
+}
+
+func formatInitState(s resource_mapv1.InitState) string {
+	switch s {
+	case resource_mapv1.InitState_INIT_STATE_NOT_STARTED:
+		return "not started"
+	case resource_mapv1.InitState_INIT_STATE_IN_PROGRESS:
+		return "in progress"
+	case resource_mapv1.InitState_INIT_STATE_COMPLETED:
+		return "completed"
+	case resource_mapv1.InitState_INIT_STATE_FAILED:
+		return "failed"
+	default:
+		return s.String()
+	}
+}
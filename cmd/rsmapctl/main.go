@@ -0,0 +1,7 @@
+package main
+
+import "github.com/daichitakahashi/rsmap/cmd/rsmapctl/app"
+
+func main() {
+	app.Run()
+}
@@ -0,0 +1,51 @@
+package app
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/daichitakahashi/rsmap"
+)
+
+func Run() {
+	var (
+		executionID = pflag.StringP("execution-id", "e", os.Getenv(rsmap.EnvExecutionID), "")
+		idleTimeout = pflag.DurationP("idle-timeout", "i", 0, "")
+		listenAddr  = pflag.StringP("listen", "l", "127.0.0.1:0", "")
+		dashboard   = pflag.BoolP("dashboard", "d", false, "")
+	)
+	pflag.Parse()
+
+	rsmapDir := pflag.Arg(0)
+	if rsmapDir == "" {
+		log.Fatal("rsmap directory must be specified")
+	}
+	if *executionID == "" {
+		log.Fatalf("execution ID must be specified by --execution-id or %s", rsmap.EnvExecutionID)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, rsmapDir, *executionID, *idleTimeout, *listenAddr, *dashboard); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(ctx context.Context, rsmapDir, executionID string, idleTimeout time.Duration, listenAddr string, dashboard bool) error {
+	opts := []*rsmap.NewOption{
+		rsmap.WithExecutionID(executionID),
+		rsmap.WithIdleTimeout(idleTimeout),
+		rsmap.WithListenAddr(listenAddr),
+	}
+	if dashboard {
+		opts = append(opts, rsmap.WithDashboard())
+	}
+	return rsmap.Serve(ctx, rsmapDir, opts...)
+}
@@ -0,0 +1,43 @@
+package app
+
+import (
+	"fmt"
+
+	"go.etcd.io/bbolt"
+
+	"github.com/daichitakahashi/rsmap/logs"
+)
+
+// Print anomalies found in logs. If any, error is returned to exit with non-zero code.
+func check(db *bbolt.DB, opts options) error {
+	filter, err := opts.windowFilter(db)
+	if err != nil {
+		return err
+	}
+	filter.Operations = nil
+	events, err := logs.ReadEvents(db, filter)
+	if err != nil {
+		return err
+	}
+	anomalies := logs.Check(events)
+	if len(anomalies) == 0 {
+		fmt.Println("No anomalies found.")
+		return nil
+	}
+
+	pathShortener, err := newPathShortener()
+	if err != nil {
+		return err
+	}
+	tbl := newTable("Time", "Resource", "Anomaly", "Message", "Context(Map->Resource)")
+	for _, a := range anomalies {
+		for _, c := range a.Event.Context {
+			if err := pathShortener.shorten(c); err != nil {
+				return err
+			}
+		}
+		tbl.AddRow(a.Event.Timestamp.Format("2006-01-02 15:04:05.999999999"), a.Event.Resource, a.Kind, a.Message, formatContext(a.Event.Context, opts.shortContext))
+	}
+	tbl.Print()
+	return fmt.Errorf("%d anomalies found", len(anomalies))
+}
@@ -0,0 +1,134 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/daichitakahashi/rsmap/logs"
+)
+
+// Compare two executions, each of which is specified by the path or the execution ID in `.rsmap`.
+func diff(a, b string, opts options) error {
+	eventsA, err := readExecution(a, opts)
+	if err != nil {
+		return err
+	}
+	eventsB, err := readExecution(b, opts)
+	if err != nil {
+		return err
+	}
+	diffs := logs.Diff(eventsA, eventsB)
+
+	tbl := newTable("Resource", "Change", "Total wait", "Total hold", "Init")
+	for _, d := range diffs {
+		var (
+			change string
+			a, b   logs.ResourceStats
+		)
+		switch {
+		case d.A == nil:
+			change = "added"
+			b = *d.B
+		case d.B == nil:
+			change = "removed"
+			a = *d.A
+		default:
+			a, b = *d.A, *d.B
+		}
+		tbl.AddRow(
+			d.Resource,
+			change,
+			formatDurationChange(a.TotalWait, b.TotalWait),
+			formatDurationChange(a.TotalHold, b.TotalHold),
+			formatInitChange(a.InitResult, b.InitResult),
+		)
+	}
+	tbl.Print()
+
+	var newCallers bool
+	tbl = newTable("Resource", "New caller")
+	for _, d := range diffs {
+		for _, c := range d.NewCallers {
+			tbl.AddRow(d.Resource, c)
+			newCallers = true
+		}
+	}
+	if newCallers {
+		fmt.Println()
+		tbl.Print()
+	}
+	return nil
+}
+
+func readExecution(execution string, opts options) ([]logs.Event, error) {
+	var (
+		filename string
+		err      error
+	)
+	if _, statErr := os.Stat(execution); statErr == nil {
+		filename, err = findLogsDB(execution, false, "")
+	} else {
+		filename, err = findLogsDB(".rsmap", false, execution)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("execution %q not found", execution)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := openDB(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	filter, err := opts.windowFilter(db)
+	if err != nil {
+		return nil, err
+	}
+	filter.Operations = []string{"init", "acquire"}
+	events, err := logs.ReadEvents(db, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Shorten context filepaths, to compare callers by location.
+	pathShortener, err := newPathShortener()
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range events {
+		for _, c := range e.Context {
+			if err := pathShortener.shorten(c); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return events, nil
+}
+
+func formatDurationChange(a, b time.Duration) string {
+	a, b = a.Round(time.Microsecond), b.Round(time.Microsecond)
+	diff := b - a
+	if diff >= 0 {
+		return fmt.Sprintf("%s -> %s (+%s)", a, b, diff)
+	}
+	return fmt.Sprintf("%s -> %s (%s)", a, b, diff)
+}
+
+func formatInitChange(a, b string) string {
+	if a == "" {
+		a = "-"
+	}
+	if b == "" {
+		b = "-"
+	}
+	if a == b {
+		return a
+	}
+	return a + " -> " + b
+}
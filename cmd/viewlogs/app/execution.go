@@ -0,0 +1,135 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"go.etcd.io/bbolt"
+
+	"github.com/daichitakahashi/rsmap/logs"
+)
+
+var errNoExecutionSelected = errors.New("execution is not selected")
+
+type execution struct {
+	id      string
+	logsDB  string
+	modTime time.Time
+}
+
+// Find logs.db from the path, which is logs.db itself, the execution directory or the rsmap directory.
+// For the rsmap directory, the execution is selected by latest or executionID, otherwise errNoExecutionSelected is returned.
+func findLogsDB(path string, latest bool, executionID string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return path, nil
+	}
+	if _, err := os.Stat(filepath.Join(path, "logs.db")); err == nil {
+		return filepath.Join(path, "logs.db"), nil
+	}
+
+	switch {
+	case executionID != "":
+		filename := filepath.Join(path, executionID, "logs.db")
+		if _, err := os.Stat(filename); err != nil {
+			return "", fmt.Errorf("execution %q not found: %w", executionID, err)
+		}
+		return filename, nil
+	case latest:
+		executions, err := findExecutions(path)
+		if err != nil {
+			return "", err
+		}
+		if len(executions) == 0 {
+			return "", fmt.Errorf("no executions found in %s", path)
+		}
+		return executions[0].logsDB, nil
+	default:
+		return "", errNoExecutionSelected
+	}
+}
+
+// Find executions having logs.db under rsmapDir, from the newest one.
+func findExecutions(rsmapDir string) ([]execution, error) {
+	entries, err := os.ReadDir(rsmapDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var executions []execution
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		filename := filepath.Join(rsmapDir, entry.Name(), "logs.db")
+		info, err := os.Stat(filename)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		executions = append(executions, execution{
+			id:      entry.Name(),
+			logsDB:  filename,
+			modTime: info.ModTime(),
+		})
+	}
+	slices.SortFunc(executions, func(a, b execution) int {
+		return b.modTime.Compare(a.modTime)
+	})
+	return executions, nil
+}
+
+// Print executions under rsmapDir, from the newest one.
+func listExecutions(rsmapDir string) error {
+	executions, err := findExecutions(rsmapDir)
+	if err != nil {
+		return err
+	}
+
+	tbl := newTable("Execution ID", "Started", "Ended", "Servers", "Resources")
+	for _, e := range executions {
+		s, err := summarize(e.logsDB)
+		if errors.Is(err, bbolt.ErrTimeout) {
+			tbl.AddRow(e.id, "-", "running", "-", "-")
+			continue
+		} else if errors.Is(err, logs.ErrIncompatibleSchema) {
+			tbl.AddRow(e.id, "-", "incompatible", "-", "-")
+			continue
+		} else if err != nil {
+			return err
+		}
+		tbl.AddRow(e.id, formatSummaryTime(s.Start), formatSummaryTime(s.End), s.Servers, strings.Join(s.Resources, ","))
+	}
+	tbl.Print()
+	return nil
+}
+
+func summarize(filename string) (*logs.Summary, error) {
+	db, err := bbolt.Open(filename, 0644, &bbolt.Options{
+		ReadOnly: true,
+		Timeout:  100 * time.Millisecond,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	return logs.Summarize(db)
+}
+
+func formatSummaryTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format(time.DateTime)
+}
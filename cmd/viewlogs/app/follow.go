@@ -0,0 +1,213 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"time"
+
+	connect_go "github.com/bufbuild/connect-go"
+	"github.com/fatih/color"
+
+	logsv1 "github.com/daichitakahashi/rsmap/internal/proto/logs/v1"
+	resource_mapv1 "github.com/daichitakahashi/rsmap/internal/proto/resource_map/v1"
+	"github.com/daichitakahashi/rsmap/internal/proto/resource_map/v1/resource_mapv1connect"
+	"github.com/daichitakahashi/rsmap/logs"
+)
+
+var errNotRunning = errors.New("server is not running")
+
+const (
+	// Wait for the server taking over by failover.
+	reconnectAttempts = 10
+	reconnectInterval = 200 * time.Millisecond
+)
+
+type followPrinter struct {
+	filter       logs.Filter
+	shortContext bool
+	shortener    *pathShortener
+
+	// Address of the server already printed, not to print the launch again on reconnection.
+	launched string
+	// Acquisition state of each resource, keyed by resource name and then by operator.
+	acquiring map[string]map[string]int64
+	acquired  map[string]map[string]int64
+}
+
+// Stream events from the server written in addrFile, until the run is over.
+// If the server is not running at first, errNotRunning is returned.
+func follow(ctx context.Context, addrFile string, opts options) error {
+	shortener, err := newPathShortener()
+	if err != nil {
+		return err
+	}
+	p := &followPrinter{
+		filter:       opts.filter(),
+		shortContext: opts.shortContext,
+		shortener:    shortener,
+		acquiring:    map[string]map[string]int64{},
+		acquired:     map[string]map[string]int64{},
+	}
+
+	var connected bool
+	for attempt := 0; ; {
+		addr, err := readAddr(addrFile)
+		if err == nil {
+			received, err := watch(ctx, addr, p.filter.Resources, p.print)
+			if err != nil {
+				return err
+			}
+			if received {
+				connected = true
+				attempt = 0
+				continue
+			}
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		if !connected {
+			return errNotRunning
+		}
+
+		// Stream is closed. Wait for the new server, or finish if the run is over.
+		attempt++
+		if attempt > reconnectAttempts {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(reconnectInterval):
+		}
+	}
+}
+
+func readAddr(addrFile string) (string, error) {
+	data, err := os.ReadFile(addrFile)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes.TrimSpace(data)), nil
+}
+
+// Receive events until the stream is closed, and report whether any event is received.
+// Failure of the stream is not an error, because the server may be stopped. Only the error of fn is returned.
+func watch(ctx context.Context, addr string, resources []string, fn func(resp *resource_mapv1.WatchResponse) error) (bool, error) {
+	cli := resource_mapv1connect.NewResourceMapServiceClient(http.DefaultClient, addr)
+	stream, err := cli.Watch(ctx, connect_go.NewRequest(&resource_mapv1.WatchRequest{
+		ResourceNames: resources,
+	}))
+	if err != nil {
+		return false, nil
+	}
+	defer func() {
+		_ = stream.Close()
+	}()
+
+	var received bool
+	for stream.Receive() {
+		received = true
+		if err := fn(stream.Msg()); err != nil {
+			return received, err
+		}
+	}
+	return received, nil
+}
+
+func (p *followPrinter) print(resp *resource_mapv1.WatchResponse) error {
+	var (
+		ts        int64
+		operation string
+		data      string
+		cc        logs.CallerContext
+	)
+	switch ev := resp.Event.(type) {
+	case *resource_mapv1.WatchResponse_Server:
+		if !p.operation("server") {
+			return nil
+		}
+		ts, cc = ev.Server.Timestamp, ev.Server.Context
+		operation = formatServerOperation(ev.Server.Event)
+		if ev.Server.Event == logsv1.ServerEvent_SERVER_EVENT_LAUNCHED {
+			if ev.Server.Addr == p.launched {
+				return nil
+			}
+			p.launched = ev.Server.Addr
+			data = ev.Server.Addr
+		}
+	case *resource_mapv1.WatchResponse_Init:
+		if !p.operation("init") {
+			return nil
+		}
+		ts, cc = ev.Init.Timestamp, ev.Init.Context
+		operation = formatAdmin(formatInitOperation(ev.Init.Event), ev.Init.Admin)
+	case *resource_mapv1.WatchResponse_Acquisition:
+		if !p.operation("acquire") {
+			return nil
+		}
+		ts, cc = ev.Acquisition.Timestamp, ev.Acquisition.Context
+		operation = formatAdmin(formatAcquisitionOperation(ev.Acquisition.Event), ev.Acquisition.Admin)
+		data = p.acquisitionData(resp.ResourceName, ev.Acquisition)
+	default:
+		return nil
+	}
+	if p.filter.Test != "" && resp.ResourceName != "" && !cc.MatchTest(p.filter.Test) {
+		return nil
+	}
+
+	for _, c := range cc {
+		if err := p.shortener.shorten(c); err != nil {
+			return err
+		}
+	}
+	timestamp := time.Unix(0, ts).Format("2006-01-02 15:04:05.000000000")
+	fmt.Printf("%s  %-16s  %-22s  %-20s  %s\n",
+		color.New(color.FgYellow).Sprint(timestamp), resp.ResourceName, operation, data, formatContext(cc, p.shortContext))
+	return nil
+}
+
+func (p *followPrinter) operation(op string) bool {
+	return len(p.filter.Operations) == 0 || slices.Contains(p.filter.Operations, op)
+}
+
+func (p *followPrinter) acquisitionData(resource string, l *logsv1.AcquisitionLog) string {
+	if p.acquiring[resource] == nil {
+		p.acquiring[resource] = map[string]int64{}
+		p.acquired[resource] = map[string]int64{}
+	}
+	var (
+		acquiring = p.acquiring[resource]
+		acquired  = p.acquired[resource]
+		cc        = logs.OperatorID(l)
+	)
+	switch l.Event {
+	case logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING:
+		if _, ok := acquiring[cc]; !ok {
+			acquiring[cc] = l.Timestamp
+		}
+	case logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED:
+		acquired[cc] = l.N
+		if start, ok := acquiring[cc]; ok {
+			delete(acquiring, cc)
+			return fmt.Sprintf("+%d [waited %s]", l.N, time.Duration(l.Timestamp-start))
+		}
+		return fmt.Sprintf("+%d", l.N)
+	case logsv1.AcquisitionEvent_ACQUISITION_EVENT_CANCELED:
+		if start, ok := acquiring[cc]; ok {
+			delete(acquiring, cc)
+			return fmt.Sprintf("[waited %s]", time.Duration(l.Timestamp-start))
+		}
+	case logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED:
+		if n, ok := acquired[cc]; ok {
+			delete(acquired, cc)
+			return fmt.Sprintf("-%d", n)
+		}
+	}
+	return ""
+}
@@ -1,47 +1,176 @@
 package app
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/pflag"
 	"go.etcd.io/bbolt"
 
-	logsv1 "github.com/daichitakahashi/rsmap/internal/proto/logs/v1"
 	"github.com/daichitakahashi/rsmap/logs"
 )
 
+type options struct {
+	operation    string
+	resource     string
+	test         string
+	shortContext bool
+	format       string
+	html         string
+	stats        bool
+	check        bool
+	since        string
+	until        string
+}
+
+// Filter of events specified by options, except the time window.
+func (o options) filter() logs.Filter {
+	var f logs.Filter
+	if o.operation != "" {
+		f.Operations = strings.Split(o.operation, ",")
+	}
+	if o.resource != "" {
+		f.Resources = []string{o.resource}
+	}
+	f.Test = o.test
+	return f
+}
+
+// Filter of events specified by options, including the time window relative to the execution of db.
+func (o options) windowFilter(db *bbolt.DB) (logs.Filter, error) {
+	f := o.filter()
+	var err error
+	f.Since, f.Until, err = parseWindow(db, o.since, o.until)
+	return f, err
+}
+
 func Run() {
 	var (
-		operation    = pflag.StringP("operation", "o", "", "")
-		resource     = pflag.StringP("resource", "r", "", "")
-		shortContext = pflag.BoolP("short", "s", false, "")
+		opts        options
+		followMode  = pflag.Bool("follow", false, "")
+		latest      = pflag.Bool("latest", false, "")
+		executionID = pflag.StringP("execution", "e", "", "")
 	)
+	pflag.StringVarP(&opts.operation, "operation", "o", "", "")
+	pflag.StringVarP(&opts.resource, "resource", "r", "", "")
+	pflag.StringVarP(&opts.test, "test", "t", "", "")
+	pflag.BoolVarP(&opts.shortContext, "short", "s", false, "")
+	pflag.StringVarP(&opts.format, "format", "f", "table", "")
+	pflag.StringVar(&opts.html, "html", "", "")
+	pflag.BoolVar(&opts.stats, "stats", false, "")
+	pflag.BoolVar(&opts.check, "check", false, "")
+	pflag.StringVar(&opts.since, "since", "", "")
+	pflag.StringVar(&opts.until, "until", "", "")
 	pflag.Parse()
 
-	filename := pflag.Arg(0)
-	if filename == "" {
-		log.Fatal("logs.db file must be specified")
+	if pflag.Arg(0) == "diff" {
+		if pflag.NArg() != 3 {
+			log.Fatal("usage: viewlogs diff <execution-a> <execution-b>")
+		}
+		if err := diff(pflag.Arg(1), pflag.Arg(2), opts); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	path := pflag.Arg(0)
+	if path == "" {
+		path = ".rsmap"
+	}
+	filename, err := findLogsDB(path, *latest, *executionID)
+	if errors.Is(err, errNoExecutionSelected) {
+		if err := listExecutions(path); err != nil {
+			log.Fatal(err)
+		}
+		return
+	} else if err != nil {
+		log.Fatal(err)
+	}
+
+	if *followMode {
+		if opts.since != "" || opts.until != "" {
+			log.Fatal("--since and --until cannot be used with --follow")
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		err := follow(ctx, filepath.Join(filepath.Dir(filename), "addr"), opts)
+		if err == nil {
+			return
+		} else if !errors.Is(err, errNotRunning) {
+			log.Fatal(err)
+		}
+		// The run is already over.
 	}
 
-	if err := run(filename, *operation, *resource, *shortContext); err != nil {
+	if err := run(filename, opts); err != nil {
 		log.Fatal(err)
 	}
 }
 
-func run(filename, operation, resource string, shortContext bool) error {
+// Open logs.db read-only, so that viewing never migrates or blocks writers of the database.
+// The database written by the newer rsmap is refused.
+func openDB(filename string) (*bbolt.DB, error) {
 	_, err := os.Stat(filename)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	db, err := bbolt.Open(filename, 0644, &bbolt.Options{
+		ReadOnly: true,
+		Timeout:  100 * time.Millisecond,
+	})
+	if errors.Is(err, bbolt.ErrTimeout) {
+		return nil, errors.New("failed to open database: locked by the running server, use --follow to see events of the run")
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to open database: %s", err)
 	}
+	if err := logs.CheckSchema(db); err != nil {
+		return nil, errors.Join(fmt.Errorf("cannot read %s: %w", filename, err), db.Close())
+	}
+	return db, nil
+}
 
-	db, err := bbolt.Open(filename, 0644, nil)
+func run(filename string, opts options) error {
+	db, err := openDB(filename)
 	if err != nil {
-		return fmt.Errorf("failed to open database: %s", err)
+		return err
 	}
 
+	if opts.stats {
+		return printStats(db, opts)
+	}
+	if opts.check {
+		return check(db, opts)
+	}
+
+	if opts.format != "table" || opts.html != "" {
+		f, err := opts.windowFilter(db)
+		if err != nil {
+			return err
+		}
+		filter := logs.ExportFilter{
+			Filter: f,
+			Format: logs.ExportFormat(opts.format),
+		}
+		if opts.html != "" {
+			filter.Format = logs.FormatHTML
+			return exportFile(opts.html, db, filter)
+		}
+		return logs.Export(os.Stdout, db, filter)
+	}
+
+	var (
+		operation = opts.operation
+		resource  = opts.resource
+	)
 	var server, init, acquire bool
 	if operation == "" {
 		server = true
@@ -61,47 +190,28 @@ func run(filename, operation, resource string, shortContext bool) error {
 	}
 
 	var resources []string
-	if resource == "" {
-		resources, err = allResources(db)
-		if err != nil {
-			return err
-		}
-	} else {
+	if resource != "" {
 		resources = []string{resource}
 	}
+	records, err := logs.ReadRecords(db, resources)
+	if err != nil {
+		return err
+	}
 
-	table := newTablePrinter(len(resources) == 1, shortContext)
+	filter, err := opts.windowFilter(db)
+	if err != nil {
+		return err
+	}
+	table := newTablePrinter(len(records.Resources) == 1, opts.shortContext, filter)
 	if server {
-		store, err := logs.NewInfoStore(db)
-		if err != nil {
-			return err
-		}
-		table.insertServerLogs(store.ServerRecord().Logs)
+		table.insertServerLogs(records.Server.Logs)
 	}
 
-	for _, resource := range resources {
-
-		if init {
-			store, err := logs.NewResourceRecordStore[logsv1.InitRecord](db)
-			if err != nil {
-				return err
-			}
-			r, err := store.Get(resource)
-			if err != nil {
-				return err
-			}
+	for _, resource := range records.Resources {
+		if r, ok := records.Init[resource]; init && ok {
 			table.insertInitLogs(resource, r.Logs)
 		}
-
-		if acquire {
-			store, err := logs.NewResourceRecordStore[logsv1.AcquisitionRecord](db)
-			if err != nil {
-				return err
-			}
-			r, err := store.Get(resource)
-			if err != nil {
-				return err
-			}
+		if r, ok := records.Acquisition[resource]; acquire && ok {
 			table.insertAcquisitionLogs(resource, r)
 		}
 	}
@@ -109,18 +219,50 @@ func run(filename, operation, resource string, shortContext bool) error {
 	return table.print()
 }
 
-func allResources(db *bbolt.DB) ([]string, error) {
-	var resources []string
-
-	err := db.View(func(tx *bbolt.Tx) error {
-		c := tx.Bucket([]byte("init")).Cursor()
-		for k, _ := c.First(); k != nil; k, _ = c.Next() {
-			resources = append(resources, string(k))
+// Parse the time window. Each bound is the time, or the duration from the start of the execution.
+func parseWindow(db *bbolt.DB, since, until string) (time.Time, time.Time, error) {
+	var start time.Time
+	parse := func(s string) (time.Time, error) {
+		if s == "" {
+			return time.Time{}, nil
 		}
-		return nil
-	})
+		if d, err := time.ParseDuration(s); err == nil {
+			if start.IsZero() {
+				summary, err := logs.Summarize(db)
+				if err != nil {
+					return time.Time{}, err
+				}
+				start = summary.Start
+			}
+			return start.Add(d), nil
+		}
+		for _, layout := range []string{time.RFC3339Nano, time.DateTime} {
+			if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("invalid time or duration: %s", s)
+	}
+
+	sinceTime, err := parse(since)
 	if err != nil {
-		return nil, err
+		return time.Time{}, time.Time{}, err
+	}
+	untilTime, err := parse(until)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return sinceTime, untilTime, nil
+}
+
+func exportFile(filename string, db *bbolt.DB, filter logs.ExportFilter) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err := logs.Export(f, db, filter); err != nil {
+		_ = f.Close()
+		return err
 	}
-	return resources, nil
+	return f.Close()
 }
@@ -0,0 +1,63 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"go.etcd.io/bbolt"
+
+	"github.com/daichitakahashi/rsmap/logs"
+)
+
+// Number of callers shown for each resource.
+const topCallers = 3
+
+func printStats(db *bbolt.DB, opts options) error {
+	filter, err := opts.windowFilter(db)
+	if err != nil {
+		return err
+	}
+	filter.Operations = []string{"init", "acquire"}
+	events, err := logs.ReadEvents(db, filter)
+	if err != nil {
+		return err
+	}
+	stats := logs.Stats(events)
+
+	tbl := newTable("Resource", "Acquired(Shared/Exclusive)", "Wait(p50/p95/max)", "Hold(p50/p95/max)", "Peak/Max", "Init", "Retries")
+	for _, s := range stats {
+		tbl.AddRow(
+			s.Resource,
+			fmt.Sprintf("%d/%d", s.Shared, s.Exclusive),
+			formatDurationStats(s.Wait),
+			formatDurationStats(s.Hold),
+			fmt.Sprintf("%d/%d", s.PeakConcurrency, s.Max),
+			s.InitDuration.Round(time.Microsecond),
+			s.InitRetries,
+		)
+	}
+	tbl.Print()
+	fmt.Println()
+
+	pathShortener, err := newPathShortener()
+	if err != nil {
+		return err
+	}
+	tbl = newTable("Resource", "Total wait", "Acquired", "Caller")
+	for _, s := range stats {
+		for _, c := range s.Callers[:min(len(s.Callers), topCallers)] {
+			for _, caller := range c.Context {
+				if err := pathShortener.shorten(caller); err != nil {
+					return err
+				}
+			}
+			tbl.AddRow(s.Resource, c.TotalWait, c.Acquisitions, formatContext(c.Context, opts.shortContext))
+		}
+	}
+	tbl.Print()
+	return nil
+}
+
+func formatDurationStats(s logs.DurationStats) string {
+	return fmt.Sprintf("%s/%s/%s", s.P50.Round(time.Microsecond), s.P95.Round(time.Microsecond), s.Max.Round(time.Microsecond))
+}
@@ -29,21 +29,35 @@ type (
 	tablePrinter struct {
 		singleResource bool
 		shortContext   bool
+		test           string
+		since, until   time.Time
 
 		rows []row
 	}
 )
 
-func newTablePrinter(singleResource, shortContext bool) *tablePrinter {
+func newTablePrinter(singleResource, shortContext bool, filter logs.Filter) *tablePrinter {
 	return &tablePrinter{
 		singleResource: singleResource,
 		shortContext:   shortContext,
+		test:           filter.Test,
+		since:          filter.Since,
+		until:          filter.Until,
 
 		rows: []row{},
 	}
 }
 
 func (p *tablePrinter) insert(v row) {
+	// Server events are always shown.
+	if p.test != "" && v.resource != "" && !v.context.MatchTest(p.test) {
+		return
+	}
+	// Rows are inserted after the state is computed from all logs, so the window only hides them.
+	ts := time.Unix(0, v.ts)
+	if (!p.since.IsZero() && ts.Before(p.since)) || (!p.until.IsZero() && !ts.Before(p.until)) {
+		return
+	}
 	idx, _ := slices.BinarySearchFunc(p.rows, v, func(r1, r2 row) int {
 		switch {
 		case r1.ts == r2.ts:
@@ -84,7 +98,7 @@ func (p *tablePrinter) insertInitLogs(resource string, il []*logsv1.InitLog) {
 		p.insert(row{
 			ts:        l.Timestamp,
 			resource:  resource,
-			operation: formatInitOperation(l.Event),
+			operation: formatAdmin(formatInitOperation(l.Event), l.Admin),
 			context:   logs.CallerContext(l.Context),
 		})
 	}
@@ -100,7 +114,7 @@ func (p *tablePrinter) insertAcquisitionLogs(resource string, r *logsv1.Acquisit
 	for _, l := range r.Logs {
 		var (
 			data string
-			cc   = logs.CallerContext(l.Context).ShortString()
+			cc   = logs.OperatorID(l)
 		)
 		switch l.Event {
 		case logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING:
@@ -119,6 +133,11 @@ func (p *tablePrinter) insertAcquisitionLogs(resource string, r *logsv1.Acquisit
 			total += l.N
 			acquired[cc] = l.N
 			data = fmt.Sprintf("+%d(%d/%d)%s", l.N, total, r.Max, elapsed)
+		case logsv1.AcquisitionEvent_ACQUISITION_EVENT_CANCELED:
+			if start, ok := acquiring[cc]; ok {
+				delete(acquiring, cc)
+				data = fmt.Sprintf("[waited %s]", time.Duration(l.Timestamp-start))
+			}
 		case logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED:
 			if n, ok := acquired[cc]; ok {
 				total -= n
@@ -129,7 +148,7 @@ func (p *tablePrinter) insertAcquisitionLogs(resource string, r *logsv1.Acquisit
 		p.insert(row{
 			ts:        l.Timestamp,
 			resource:  resource,
-			operation: formatAcquisitionOperation(l.Event),
+			operation: formatAdmin(formatAcquisitionOperation(l.Event), l.Admin),
 			context:   logs.CallerContext(l.Context),
 			data:      data,
 		})
@@ -144,19 +163,11 @@ func (p *tablePrinter) print() error {
 
 	var tbl table.Table
 	if p.singleResource {
-		tbl = table.New("Time", "Elapsed", "Operation", "Data", "Context(Map->Resource)")
+		tbl = newTable("Time", "Elapsed", "Operation", "Data", "Context(Map->Resource)")
 	} else {
-		tbl = table.New("Time", "Resource", "Operation", "Data", "Context(Map->Resource)")
+		tbl = newTable("Time", "Resource", "Operation", "Data", "Context(Map->Resource)")
 	}
 
-	tbl = tbl.
-		WithHeaderFormatter(
-			color.New(color.FgGreen, color.Underline).SprintfFunc(),
-		).
-		WithFirstColumnFormatter(
-			color.New(color.FgYellow).SprintfFunc(),
-		)
-
 	var last time.Time
 	for _, r := range p.rows {
 		timestamp, elapsed := formatTime(r.ts, &last)
@@ -167,13 +178,7 @@ func (p *tablePrinter) print() error {
 				return err
 			}
 		}
-		var ctx string
-		if p.shortContext {
-			ctx = r.context.ShortString()
-		} else {
-			ctx = r.context.String()
-		}
-
+		ctx := formatContext(r.context, p.shortContext)
 		if p.singleResource {
 			tbl.AddRow(timestamp, elapsed, r.operation, r.data, ctx)
 		} else {
@@ -185,6 +190,30 @@ func (p *tablePrinter) print() error {
 	return nil
 }
 
+func newTable(columns ...any) table.Table {
+	return table.New(columns...).
+		WithHeaderFormatter(
+			color.New(color.FgGreen, color.Underline).SprintfFunc(),
+		).
+		WithFirstColumnFormatter(
+			color.New(color.FgYellow).SprintfFunc(),
+		)
+}
+
+// Format caller context with the name of the test, if set.
+func formatContext(cc logs.CallerContext, short bool) string {
+	var s string
+	if short {
+		s = cc.ShortString()
+	} else {
+		s = cc.String()
+	}
+	if test := cc.Test(); test != "" {
+		s += " [" + test + "]"
+	}
+	return s
+}
+
 func formatServerOperation(e logsv1.ServerEvent) string {
 	switch e {
 	case logsv1.ServerEvent_SERVER_EVENT_LAUNCHED:
@@ -204,6 +233,8 @@ func formatInitOperation(e logsv1.InitEvent) string {
 		return "init:completed"
 	case logsv1.InitEvent_INIT_EVENT_FAILED:
 		return "init:failed"
+	case logsv1.InitEvent_INIT_EVENT_RESET:
+		return "init:reset"
 	default:
 		return e.String()
 	}
@@ -211,8 +242,12 @@ func formatInitOperation(e logsv1.InitEvent) string {
 
 func formatAcquisitionOperation(e logsv1.AcquisitionEvent) string {
 	switch e {
+	case logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING:
+		return "acquiring"
 	case logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED:
 		return "acquired"
+	case logsv1.AcquisitionEvent_ACQUISITION_EVENT_CANCELED:
+		return "canceled"
 	case logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED:
 		return "released"
 	default:
@@ -220,6 +255,13 @@ func formatAcquisitionOperation(e logsv1.AcquisitionEvent) string {
 	}
 }
 
+func formatAdmin(operation string, admin bool) string {
+	if admin {
+		return operation + "(admin)"
+	}
+	return operation
+}
+
 func formatTime(ts int64, last *time.Time) (string, string) {
 	t := time.Unix(0, ts)
 	defer func() {
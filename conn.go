@@ -3,16 +3,25 @@ package rsmap
 import (
 	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
+	"path/filepath"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	connect_go "github.com/bufbuild/connect-go"
 	"github.com/daichitakahashi/deps"
 	"github.com/lestrrat-go/backoff/v2"
 	"go.etcd.io/bbolt"
+	"go.opentelemetry.io/otel/trace"
 
 	logsv1 "github.com/daichitakahashi/rsmap/internal/proto/logs/v1"
 	resource_mapv1 "github.com/daichitakahashi/rsmap/internal/proto/resource_map/v1"
@@ -21,16 +30,26 @@ import (
 )
 
 type config struct {
-	dbFile      string
+	dir         string
+	backend     func(ctx context.Context, dir string) (logs.Backend, error)
 	addrFile    string
+	daemonFile  string
 	retryPolicy backoff.Policy
 	httpCli     *http.Client
+	idleTimeout time.Duration
+	serverAddr  string
+	listenAddr  string
+	fileLock    bool
+	tracer      trace.Tracer
+	logger      *slog.Logger
+	dashboard   bool
+	retention   retention
 }
 
-// Open database for server.
-// This blocks indefinitely.
-func (c *config) openDB() (*bbolt.DB, error) {
-	return bbolt.Open(c.dbFile, 0644, nil) // Set options if required.
+// Open backend for server.
+// This blocks while other server holds the backend, until ctx is done.
+func (c *config) openBackend(ctx context.Context) (logs.Backend, error) {
+	return c.backend(ctx, c.dir)
 }
 
 // Read server address.
@@ -47,6 +66,64 @@ func (c *config) writeAddr(addr string) error {
 	return os.WriteFile(c.addrFile, []byte(addr), 0644)
 }
 
+// Check the schema version of `logs.db` if exists, so that the incompatible database is refused by New,
+// rather than by the server launched in background.
+// The database locked by the running server is not checked, because the server has already accepted it.
+func (c *config) checkSchema() error {
+	filename := filepath.Join(c.dir, "logs.db")
+	if _, err := os.Stat(filename); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	db, err := bbolt.Open(filename, 0644, &bbolt.Options{
+		ReadOnly: true,
+		Timeout:  time.Nanosecond,
+	})
+	if errors.Is(err, bbolt.ErrTimeout) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("rsmap: failed to open logs.db: %w", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	if err := logs.CheckSchema(db); err != nil {
+		return fmt.Errorf("rsmap: %w", err)
+	}
+	return nil
+}
+
+// Mark that the server is hosted by the daemon.
+func (c *config) writeDaemon(addr string) error {
+	return os.WriteFile(c.daemonFile, []byte(addr), 0644)
+}
+
+// Unmark the daemon.
+func (c *config) removeDaemon() error {
+	return os.Remove(c.daemonFile)
+}
+
+// Check whether the daemon is running and reachable.
+// Stale mark left by the killed daemon is ignored.
+func (c *config) daemonRunning() bool {
+	data, err := os.ReadFile(c.daemonFile)
+	if err != nil {
+		return false
+	}
+	u, err := url.Parse(string(bytes.TrimSpace(data)))
+	if err != nil {
+		return false
+	}
+	conn, err := net.DialTimeout("tcp", u.Host, time.Second)
+	if err != nil {
+		return false
+	}
+	_ = conn.Close()
+	return true
+}
+
 var (
 	serverMu  sync.Mutex
 	serverSem = make(map[string]chan struct{})
@@ -66,106 +143,267 @@ func (m *Map) launchServer(dir string, callers logs.CallerContext) func() {
 
 	go func(dep *deps.Dependency) (err error) {
 		defer dep.Stop(&err)
+		defer func() {
+			if err != nil {
+				m._cfg.logger.Error("rsmap: server failed", "dir", dir, "error", err)
+			}
+		}()
+
+		// Give up waiting for the backend when aborted.
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			select {
+			case <-dep.Aborted():
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		// Wait for the backend before the semaphore, so that the backend on memory knows the successor waiting for it,
+		// and hands over the state on Close.
+		m._cfg.logger.Debug("rsmap: waiting for the backend to launch server", "dir", dir)
+		b, err := m._cfg.openBackend(ctx)
+		if errors.Is(err, context.Canceled) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		defer b.Close()
 
 		select {
 		case <-dep.Aborted():
-			return
+			return nil
 		case sem <- struct{}{}: // Avoid launching multiple server from same process.
 			defer func() {
 				<-sem
 			}()
 		}
-
-		db, err := m._cfg.openDB()
-		if err != nil {
-			return err
-		}
-		defer db.Close()
-
 		select {
 		case <-dep.Aborted():
 			return nil
 		default:
 		}
 
-		info, err := logs.NewInfoStore(db)
+		s, err := startServer(m._cfg, b, callers)
 		if err != nil {
 			return err
 		}
 
-		closing := make(chan struct{})
-		rm, err := newServerSideMap(db, closing)
-		if err != nil {
-			return err
-		}
+		// Replace resourceMap with serverSideMap.
+		m._mu.Lock()
+		m._rm = s._rm
+		m._mu.Unlock()
 
-		// Launch server.
-		ln, err := net.Listen("tcp", ":0")
-		if err != nil {
-			return err
+		<-dep.Aborted()
+		return s.stop(dep.AbortContext())
+	}(root.Dependent())
+
+	return sync.OnceFunc(func() {
+		_ = root.Abort(context.Background())
+	})
+}
+
+// Act as a pure client of the daemon.
+// Once the daemon stops (e.g. by idle timeout) and the server becomes unavailable, launch the server as usual,
+// so that the retried request is served by it.
+func (m *Map) followDaemon(dir string) func() {
+	var (
+		mu     sync.Mutex
+		stop   func()
+		closed bool
+	)
+	m._rm.(*clientSideMap)._unavailable = func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if stop != nil || closed || m._cfg.daemonRunning() {
+			return
 		}
-		defer func() {
-			_ = ln.Close()
-		}()
-		mux := http.NewServeMux()
-		mux.Handle(
-			resource_mapv1connect.NewResourceMapServiceHandler(&resourceMapHandler{
-				_rm: rm,
-			}),
-		)
-		s := http.Server{
-			Handler: mux,
+		m._cfg.logger.Info("rsmap: daemon is not running, launching server", "dir", dir)
+		stop = m.launchServer(dir, m._callers)
+	}
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		closed = true
+		if stop != nil {
+			stop()
 		}
-		go func() {
-			_ = s.Serve(ln)
-		}()
+	}
+}
 
-		// Write addr for other clients.
-		addr := "http://" + ln.Addr().String()
-		err = m._cfg.writeAddr(addr)
-		if err != nil {
-			return err
+type server struct {
+	_callers  logs.CallerContext
+	_info     *logs.InfoStore
+	_rm       *serverSideMap
+	_ln       net.Listener
+	_s        *http.Server
+	_closing  chan struct{}
+	_addr     string
+	_inFlight atomic.Int64
+	_lastSeen atomic.Int64
+	_metrics  *metrics
+	_logger   *slog.Logger
+	_broker   *broker
+}
+
+// Launch server on the opened backend, and write its address for other clients.
+func startServer(cfg config, b logs.Backend, callers logs.CallerContext) (_ *server, err error) {
+	info := b.InfoStore()
+
+	closing := make(chan struct{})
+	rm, err := newServerSideMap(b, closing)
+	if err != nil {
+		return nil, err
+	}
+	mt := newMetrics()
+	rm.enableMetrics(mt)
+	rm.enableTracing(cfg.tracer)
+	br := newBroker()
+	rm.enableWatch(br)
+
+	// Former launches in this execution are counted as failovers.
+	for _, l := range info.ServerRecord().Logs {
+		if l.Event == logsv1.ServerEvent_SERVER_EVENT_LAUNCHED {
+			mt.failovers(1)
 		}
+	}
 
-		// Record launched server.
-		err = info.PutServerLog(&logsv1.ServerLog{
-			Event:     logsv1.ServerEvent_SERVER_EVENT_LAUNCHED,
-			Addr:      addr,
-			Context:   callers,
-			Timestamp: time.Now().UnixNano(),
-		})
+	// Launch server.
+	ln, err := net.Listen("tcp", cfg.listenAddr)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
 		if err != nil {
-			return err
+			_ = ln.Close()
 		}
+	}()
+	s := &server{
+		_callers: callers,
+		_info:    info,
+		_rm:      rm,
+		_ln:      ln,
+		_closing: closing,
+		_addr:    "http://" + ln.Addr().String(),
+		_metrics: mt,
+		_logger:  cfg.logger,
+		_broker:  br,
+	}
+	s._lastSeen.Store(time.Now().UnixNano())
 
-		// Replace resourceMap with serverSideMap.
-		m._mu.Lock()
-		m._rm = rm
-		m._mu.Unlock()
+	mux := http.NewServeMux()
+	mux.HandleFunc(healthPath, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.Handle(metricsPath, mt.handler())
+	handler := &resourceMapHandler{
+		_rm:         rm,
+		_broker:     br,
+		_closing:    closing,
+		_addr:       s._addr,
+		_launchedAt: time.Now(),
+	}
+	path, h := resource_mapv1connect.NewResourceMapServiceHandler(handler,
+		connect_go.WithInterceptors(traceInterceptor(cfg.tracer)),
+	)
+	mux.Handle(path, s.track(h))
+	if cfg.dashboard {
+		mux.HandleFunc(dashboardPath, handler.dashboard)
+		mux.HandleFunc(dashboardEventsPath, handler.dashboardEvents)
+	}
+	s._s = &http.Server{
+		Handler: mux,
+	}
+	go func() {
+		_ = s._s.Serve(ln)
+	}()
 
-		<-dep.Aborted()
-		close(closing)
-		_ = s.Shutdown(dep.AbortContext())
-
-		// Record stopped server.
-		return info.PutServerLog(&logsv1.ServerLog{
-			Event:     logsv1.ServerEvent_SERVER_EVENT_STOPPED,
-			Context:   callers,
-			Timestamp: time.Now().UnixNano(),
-		})
-	}(root.Dependent())
+	// Write addr for other clients.
+	err = cfg.writeAddr(s._addr)
+	if err != nil {
+		return nil, err
+	}
 
-	return sync.OnceFunc(func() {
-		_ = root.Abort(context.Background())
+	// Record launched server.
+	launched := &logsv1.ServerLog{
+		Event:     logsv1.ServerEvent_SERVER_EVENT_LAUNCHED,
+		Addr:      s._addr,
+		Context:   callers,
+		Timestamp: handler._launchedAt.UnixNano(),
+	}
+	err = info.PutServerLog(launched)
+	if err != nil {
+		return nil, err
+	}
+	br.publishServerLog(launched)
+	cfg.logger.Info("rsmap: server launched", "addr", s._addr, "dir", cfg.dir)
+
+	// Remove old execution directories, except for this one.
+	removed, err := collectGarbage(filepath.Dir(cfg.dir), cfg.retention, cfg.dir)
+	for _, dir := range removed {
+		cfg.logger.Info("rsmap: execution directory removed", "dir", dir)
+	}
+	if err != nil {
+		cfg.logger.Warn("rsmap: failed to remove execution directories", "error", err)
+	}
+	return s, nil
+}
+
+// Track in-flight requests and the time of last activity.
+// Long-lived stream of Watch is not tracked, so that watchers don't keep the server alive.
+func (s *server) track(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == resource_mapv1connect.ResourceMapServiceWatchProcedure {
+			h.ServeHTTP(w, r)
+			return
+		}
+		s._inFlight.Add(1)
+		defer func() {
+			s._lastSeen.Store(time.Now().UnixNano())
+			s._inFlight.Add(-1)
+		}()
+		h.ServeHTTP(w, r)
 	})
 }
 
+// Report whether the server has no requests, no acquired locks and no initialization in progress for the duration.
+func (s *server) idle(d time.Duration) bool {
+	if s._inFlight.Load() > 0 || s._rm._acquire.holding() || s._rm._init.initializing() {
+		return false
+	}
+	return time.Since(time.Unix(0, s._lastSeen.Load())) >= d
+}
+
+// Stop server and record it.
+func (s *server) stop(ctx context.Context) error {
+	stopped := &logsv1.ServerLog{
+		Event:     logsv1.ServerEvent_SERVER_EVENT_STOPPED,
+		Context:   s._callers,
+		Timestamp: time.Now().UnixNano(),
+	}
+	// Notify watchers before closing their streams.
+	s._broker.publishServerLog(stopped)
+
+	close(s._closing)
+	_ = s._s.Shutdown(ctx)
+	_ = s._ln.Close()
+	s._logger.Info("rsmap: server stopped", "addr", s._addr)
+
+	// Record stopped server.
+	return s._info.PutServerLog(stopped)
+}
+
 type resourceMapHandler struct {
-	_rm *serverSideMap
+	_rm         *serverSideMap
+	_broker     *broker
+	_closing    <-chan struct{}
+	_addr       string
+	_launchedAt time.Time
 }
 
 func (h *resourceMapHandler) TryInitResource(ctx context.Context, req *connect_go.Request[resource_mapv1.TryInitResourceRequest]) (*connect_go.Response[resource_mapv1.TryInitResourceResponse], error) {
-	try, err := h._rm.tryInit(ctx, req.Msg.ResourceName, logs.CallerContext(req.Msg.Context))
+	try, err := h._rm.tryInit(ctx, req.Msg.ResourceName, logs.OperatorOf(req.Msg))
 	if err != nil {
 		return nil, err
 	}
@@ -175,7 +413,7 @@ func (h *resourceMapHandler) TryInitResource(ctx context.Context, req *connect_g
 }
 
 func (h *resourceMapHandler) CompleteInitResource(ctx context.Context, req *connect_go.Request[resource_mapv1.CompleteInitResourceRequest]) (*connect_go.Response[resource_mapv1.CompleteInitResourceResponse], error) {
-	err := h._rm.completeInit(ctx, req.Msg.ResourceName, logs.CallerContext(req.Msg.Context))
+	err := h._rm.completeInit(ctx, req.Msg.ResourceName, logs.OperatorOf(req.Msg))
 	if err != nil {
 		return nil, err
 	}
@@ -183,7 +421,7 @@ func (h *resourceMapHandler) CompleteInitResource(ctx context.Context, req *conn
 }
 
 func (h *resourceMapHandler) FailInitResource(ctx context.Context, req *connect_go.Request[resource_mapv1.FailInitResourceRequest]) (*connect_go.Response[resource_mapv1.FailInitResourceResponse], error) {
-	err := h._rm.failInit(ctx, req.Msg.ResourceName, logs.CallerContext(req.Msg.Context))
+	err := h._rm.failInit(ctx, req.Msg.ResourceName, logs.OperatorOf(req.Msg))
 	if err != nil {
 		return nil, err
 	}
@@ -191,7 +429,7 @@ func (h *resourceMapHandler) FailInitResource(ctx context.Context, req *connect_
 }
 
 func (h *resourceMapHandler) Acquire(ctx context.Context, req *connect_go.Request[resource_mapv1.AcquireRequest]) (*connect_go.Response[resource_mapv1.AcquireResponse], error) {
-	err := h._rm.acquire(ctx, req.Msg.ResourceName, logs.CallerContext(req.Msg.Context), req.Msg.MaxParallelism, req.Msg.Exclusive)
+	err := h._rm.acquire(ctx, req.Msg.ResourceName, logs.OperatorOf(req.Msg), req.Msg.MaxParallelism, req.Msg.Exclusive)
 	if err != nil {
 		return nil, err
 	}
@@ -207,7 +445,7 @@ func (h *resourceMapHandler) AcquireMulti(ctx context.Context, req *connect_go.R
 }
 
 func (h *resourceMapHandler) Release(ctx context.Context, req *connect_go.Request[resource_mapv1.ReleaseRequest]) (*connect_go.Response[resource_mapv1.ReleaseResponse], error) {
-	err := h._rm.release(ctx, req.Msg.ResourceName, logs.CallerContext(req.Msg.Context))
+	err := h._rm.release(ctx, req.Msg.ResourceName, logs.OperatorOf(req.Msg))
 	if err != nil {
 		return nil, err
 	}
@@ -222,10 +460,81 @@ func (h *resourceMapHandler) ReleaseMulti(ctx context.Context, req *connect_go.R
 	return connect_go.NewResponse(&resource_mapv1.ReleaseMultiResponse{}), nil
 }
 
+func (h *resourceMapHandler) Watch(ctx context.Context, req *connect_go.Request[resource_mapv1.WatchRequest], stream *connect_go.ServerStream[resource_mapv1.WatchResponse]) error {
+	sub := h._broker.subscribe(req.Msg.ResourceNames)
+	defer h._broker.unsubscribe(sub)
+
+	return sub.stream(ctx, h._closing, stream.Send)
+}
+
+func (h *resourceMapHandler) GetStatus(_ context.Context, _ *connect_go.Request[resource_mapv1.GetStatusRequest]) (*connect_go.Response[resource_mapv1.GetStatusResponse], error) {
+	resources, err := h._rm.status()
+	if err != nil {
+		return nil, err
+	}
+	return connect_go.NewResponse(&resource_mapv1.GetStatusResponse{
+		Addr:       h._addr,
+		LaunchedAt: h._launchedAt.UnixNano(),
+		Resources:  resources,
+	}), nil
+}
+
+func (h *resourceMapHandler) ForceRelease(_ context.Context, req *connect_go.Request[resource_mapv1.ForceReleaseRequest]) (*connect_go.Response[resource_mapv1.ForceReleaseResponse], error) {
+	if err := checkAdminPeer(req.Peer().Addr); err != nil {
+		return nil, err
+	}
+	operator, err := h._rm._acquire.forceRelease(req.Msg.ResourceName, req.Msg.CallerHash)
+	if err != nil {
+		return nil, adminError(err)
+	}
+	return connect_go.NewResponse(&resource_mapv1.ForceReleaseResponse{
+		Context: operator,
+	}), nil
+}
+
+func (h *resourceMapHandler) ResetInit(_ context.Context, req *connect_go.Request[resource_mapv1.ResetInitRequest]) (*connect_go.Response[resource_mapv1.ResetInitResponse], error) {
+	if err := checkAdminPeer(req.Peer().Addr); err != nil {
+		return nil, err
+	}
+	err := h._rm._init.reset(req.Msg.ResourceName)
+	if err != nil {
+		return nil, adminError(err)
+	}
+	return connect_go.NewResponse(&resource_mapv1.ResetInitResponse{}), nil
+}
+
+// Admin operations are unauthenticated, so they are accepted only from the loopback address,
+// even if the server listens on other interfaces.
+func checkAdminPeer(addr string) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		return connect_go.NewError(connect_go.CodePermissionDenied, fmt.Errorf("rsmap: admin operation from %q is not allowed", addr))
+	}
+	return nil
+}
+
+// Convert error of admin operations, so that the client can distinguish the cause.
+func adminError(err error) error {
+	if errors.Is(err, errResourceNotFound) || errors.Is(err, errHolderNotFound) {
+		return connect_go.NewError(connect_go.CodeNotFound, err)
+	}
+	return err
+}
+
 var _ resource_mapv1connect.ResourceMapServiceHandler = (*resourceMapHandler)(nil)
 
+const healthPath = "/healthz"
+
+// Maximum time for New to wait for the server specified explicitly becoming healthy, regardless of the retry policy.
+const healthCheckTimeout = 5 * time.Second
+
 type clientSideMap struct {
 	_cfg config
+	// Called when the server is unavailable, to launch the server in place of the stopped daemon.
+	_unavailable func()
 }
 
 func newClientSideMap(cfg config) *clientSideMap {
@@ -234,6 +543,63 @@ func newClientSideMap(cfg config) *clientSideMap {
 	}
 }
 
+// Get server address. The address specified explicitly takes precedence over `addr` file.
+func (m *clientSideMap) addr() (string, error) {
+	if m._cfg.serverAddr != "" {
+		return m._cfg.serverAddr, nil
+	}
+	addr, err := m._cfg.readAddr()
+	if err != nil {
+		m._cfg.logger.Debug("rsmap: failed to read server address", "file", m._cfg.addrFile, "error", err)
+		return "", err
+	}
+	m._cfg.logger.Debug("rsmap: server address read", "file", m._cfg.addrFile, "addr", addr)
+	return addr, nil
+}
+
+// Check health of the server.
+func (m *clientSideMap) health(ctx context.Context, addr string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(addr, "/")+healthPath, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := m._cfg.httpCli.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+	return nil
+}
+
+// Wait until the server becomes healthy, according to the retry policy.
+func (m *clientSideMap) waitHealthy(ctx context.Context) error {
+	var (
+		addr string
+		err  error
+		ctl  = m._cfg.retryPolicy.Start(ctx)
+	)
+	for {
+		select {
+		case <-ctl.Done():
+			// Keep the last error of the dial or health check, which tells the cause.
+			return fmt.Errorf("rsmap: server is unreachable(%s): %w", addr, errors.Join(ctx.Err(), err))
+		case <-ctl.Next():
+			addr, err = m.addr()
+			if err != nil {
+				continue
+			}
+			if err = m.health(ctx, addr); err != nil {
+				m._cfg.logger.Info("rsmap: retrying health check", "addr", addr, "error", err)
+				continue
+			}
+			return nil
+		}
+	}
+}
+
 func (m *clientSideMap) try(ctx context.Context, op func(ctx context.Context, cli resource_mapv1connect.ResourceMapServiceClient) error) error {
 	var (
 		addr string
@@ -247,16 +613,25 @@ func (m *clientSideMap) try(ctx context.Context, op func(ctx context.Context, cl
 			if e := ctx.Err(); e != nil {
 				return e
 			}
+			if m._cfg.serverAddr != "" && err != nil {
+				return fmt.Errorf("rsmap: failed to communicate with server(%s): %w", addr, err)
+			}
 			return err
 		case <-ctl.Next():
-			addr, err = m._cfg.readAddr()
+			addr, err = m.addr()
 			if err != nil {
 				// Retry!
 				continue
 			}
 			// MEMO: Do we need to reuse service clients?
-			cli := resource_mapv1connect.NewResourceMapServiceClient(m._cfg.httpCli, addr)
+			cli := resource_mapv1connect.NewResourceMapServiceClient(m._cfg.httpCli, addr,
+				connect_go.WithInterceptors(traceInterceptor(m._cfg.tracer)),
+			)
 			if err = op(ctx, cli); err != nil {
+				if connect_go.CodeOf(err) == connect_go.CodeUnavailable && m._unavailable != nil {
+					m._unavailable()
+				}
+				m._cfg.logger.Info("rsmap: retrying request to server", "addr", addr, "error", err)
 				// Retry!
 				continue
 			}
@@ -265,12 +640,13 @@ func (m *clientSideMap) try(ctx context.Context, op func(ctx context.Context, cl
 	}
 }
 
-func (m *clientSideMap) tryInit(ctx context.Context, resourceName string, operator logs.CallerContext) (try bool, _ error) {
+func (m *clientSideMap) tryInit(ctx context.Context, resourceName string, operator logs.Operator) (try bool, _ error) {
 	err := m.try(ctx, func(ctx context.Context, cli resource_mapv1connect.ResourceMapServiceClient) error {
 
 		resp, err := cli.TryInitResource(ctx, connect_go.NewRequest(&resource_mapv1.TryInitResourceRequest{
 			ResourceName: resourceName,
-			Context:      operator,
+			Context:      operator.Context,
+			OperatorId:   operator.ID,
 		}))
 		if err != nil {
 			return err
@@ -282,36 +658,39 @@ func (m *clientSideMap) tryInit(ctx context.Context, resourceName string, operat
 	return try, err
 }
 
-func (m *clientSideMap) completeInit(ctx context.Context, resourceName string, operator logs.CallerContext) error {
+func (m *clientSideMap) completeInit(ctx context.Context, resourceName string, operator logs.Operator) error {
 	return m.try(ctx, func(ctx context.Context, cli resource_mapv1connect.ResourceMapServiceClient) error {
 
 		_, err := cli.CompleteInitResource(ctx, connect_go.NewRequest(&resource_mapv1.CompleteInitResourceRequest{
 			ResourceName: resourceName,
-			Context:      operator,
+			Context:      operator.Context,
+			OperatorId:   operator.ID,
 		}))
 
 		return err
 	})
 }
 
-func (m *clientSideMap) failInit(ctx context.Context, resourceName string, operator logs.CallerContext) error {
+func (m *clientSideMap) failInit(ctx context.Context, resourceName string, operator logs.Operator) error {
 	return m.try(ctx, func(ctx context.Context, cli resource_mapv1connect.ResourceMapServiceClient) error {
 
 		_, err := cli.FailInitResource(ctx, connect_go.NewRequest(&resource_mapv1.FailInitResourceRequest{
 			ResourceName: resourceName,
-			Context:      operator,
+			Context:      operator.Context,
+			OperatorId:   operator.ID,
 		}))
 
 		return err
 	})
 }
 
-func (m *clientSideMap) acquire(ctx context.Context, resourceName string, operator logs.CallerContext, max int64, exclusive bool) error {
+func (m *clientSideMap) acquire(ctx context.Context, resourceName string, operator logs.Operator, max int64, exclusive bool) error {
 	return m.try(ctx, func(ctx context.Context, cli resource_mapv1connect.ResourceMapServiceClient) error {
 
 		_, err := cli.Acquire(ctx, connect_go.NewRequest(&resource_mapv1.AcquireRequest{
 			ResourceName:   resourceName,
-			Context:        operator,
+			Context:        operator.Context,
+			OperatorId:     operator.ID,
 			MaxParallelism: max,
 			Exclusive:      exclusive,
 		}))
@@ -331,12 +710,13 @@ func (m *clientSideMap) acquireMulti(ctx context.Context, resources []*resource_
 	})
 }
 
-func (m *clientSideMap) release(ctx context.Context, resourceName string, operator logs.CallerContext) error {
+func (m *clientSideMap) release(ctx context.Context, resourceName string, operator logs.Operator) error {
 	return m.try(ctx, func(ctx context.Context, cli resource_mapv1connect.ResourceMapServiceClient) error {
 
 		_, err := cli.Release(ctx, connect_go.NewRequest(&resource_mapv1.ReleaseRequest{
 			ResourceName: resourceName,
-			Context:      operator,
+			Context:      operator.Context,
+			OperatorId:   operator.ID,
 		}))
 
 		return err
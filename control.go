@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/daichitakahashi/oncewait"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/daichitakahashi/rsmap/internal/ctl"
 	logsv1 "github.com/daichitakahashi/rsmap/internal/proto/logs/v1"
@@ -19,12 +22,17 @@ import (
 // TODO
 // * timeout for init and acquisition
 
-var errClosing = errors.New("closing")
+var (
+	errClosing          = errors.New("closing")
+	errResourceNotFound = errors.New("resource not found")
+	errHolderNotFound   = errors.New("holder not found")
+)
 
 type initController struct {
 	_store     logs.ResourceRecordStore[logsv1.InitRecord]
 	_resources sync.Map
 	_closing   <-chan struct{}
+	_metrics   *metrics
 }
 
 func loadInitController(store logs.ResourceRecordStore[logsv1.InitRecord], closing <-chan struct{}) (*initController, error) {
@@ -32,15 +40,15 @@ func loadInitController(store logs.ResourceRecordStore[logsv1.InitRecord], closi
 		_store:   store,
 		_closing: closing,
 	}
-	err := c._store.ForEach(func(name string, obj *logsv1.InitRecord) error {
+	err := c._store.ForEachSnapshot(func(name string, obj *logsv1.InitRecord) error {
 		if len(obj.Logs) == 0 {
 			return nil // Impossible path.
 		}
 
 		// Get last init status and operator.
 		last := obj.Logs[len(obj.Logs)-1]
-		if last.Event == logsv1.InitEvent_INIT_EVENT_FAILED {
-			return nil // Former try is failed and anyone haven't started next try yet.
+		if last.Event == logsv1.InitEvent_INIT_EVENT_FAILED || last.Event == logsv1.InitEvent_INIT_EVENT_RESET {
+			return nil // Former try is failed(or reset) and anyone haven't started next try yet.
 		}
 
 		completed := last.Event == logsv1.InitEvent_INIT_EVENT_COMPLETED
@@ -48,7 +56,7 @@ func loadInitController(store logs.ResourceRecordStore[logsv1.InitRecord], closi
 		if !completed {
 			<-initCtl.TryInit(
 				context.Background(),
-				logs.CallerContext(last.Context).String(),
+				logs.OperatorID(last),
 			)
 		}
 		c._resources.Store(name, initCtl)
@@ -60,7 +68,7 @@ func loadInitController(store logs.ResourceRecordStore[logsv1.InitRecord], closi
 	return c, nil
 }
 
-func (c *initController) tryInit(ctx context.Context, resourceName string, operator logs.CallerContext) (bool, error) {
+func (c *initController) tryInit(ctx context.Context, resourceName string, operator logs.Operator) (bool, error) {
 	v, _ := c._resources.LoadOrStore(resourceName, ctl.NewInitCtl(false))
 	initCtl := v.(*ctl.InitCtl)
 
@@ -68,7 +76,7 @@ func (c *initController) tryInit(ctx context.Context, resourceName string, opera
 	select {
 	case <-c._closing:
 		return false, errClosing
-	case result = <-initCtl.TryInit(ctx, operator.String()):
+	case result = <-initCtl.TryInit(ctx, operator.ID):
 		if result.Err != nil {
 			return false, result.Err
 		}
@@ -78,12 +86,15 @@ func (c *initController) tryInit(ctx context.Context, resourceName string, opera
 	}
 
 	if result.Initiated {
+		c._metrics.initStarted(resourceName, operator.ID)
+
 		// Update data on key value store.
 		err := c._store.Put([]string{resourceName}, func(_ string, r *logsv1.InitRecord, _ bool) {
 			r.Logs = append(r.Logs, &logsv1.InitLog{
-				Event:     logsv1.InitEvent_INIT_EVENT_STARTED,
-				Context:   operator,
-				Timestamp: time.Now().UnixNano(),
+				Event:      logsv1.InitEvent_INIT_EVENT_STARTED,
+				Context:    operator.Context,
+				OperatorId: operator.ID,
+				Timestamp:  time.Now().UnixNano(),
 			})
 		})
 		if err != nil {
@@ -93,7 +104,7 @@ func (c *initController) tryInit(ctx context.Context, resourceName string, opera
 	return true, nil
 }
 
-func (c *initController) complete(resourceName string, operator logs.CallerContext) error {
+func (c *initController) complete(resourceName string, operator logs.Operator) error {
 	select {
 	case <-c._closing:
 		return errClosing
@@ -102,25 +113,27 @@ func (c *initController) complete(resourceName string, operator logs.CallerConte
 
 	v, found := c._resources.Load(resourceName)
 	if !found {
-		return errors.New("resource not found")
+		return errResourceNotFound
 	}
 	ctl := v.(*ctl.InitCtl)
 
-	err := ctl.Complete(operator.String())
+	err := ctl.Complete(operator.ID)
 	if err != nil {
 		return err
 	}
+	c._metrics.initFinished(resourceName, operator.ID, true)
 
 	return c._store.Put([]string{resourceName}, func(_ string, r *logsv1.InitRecord, _ bool) {
 		r.Logs = append(r.Logs, &logsv1.InitLog{
-			Event:     logsv1.InitEvent_INIT_EVENT_COMPLETED,
-			Context:   operator,
-			Timestamp: time.Now().UnixNano(),
+			Event:      logsv1.InitEvent_INIT_EVENT_COMPLETED,
+			Context:    operator.Context,
+			OperatorId: operator.ID,
+			Timestamp:  time.Now().UnixNano(),
 		})
 	})
 }
 
-func (c *initController) fail(resourceName string, operator logs.CallerContext) error {
+func (c *initController) fail(resourceName string, operator logs.Operator) error {
 	select {
 	case <-c._closing:
 		return errClosing
@@ -129,24 +142,104 @@ func (c *initController) fail(resourceName string, operator logs.CallerContext)
 
 	v, found := c._resources.Load(resourceName)
 	if !found {
-		return errors.New("resource not found")
+		return errResourceNotFound
 	}
 	ctl := v.(*ctl.InitCtl)
 
-	err := ctl.Fail(operator.String())
+	err := ctl.Fail(operator.ID)
 	if err != nil {
 		return err
 	}
+	c._metrics.initFinished(resourceName, operator.ID, false)
 
 	return c._store.Put([]string{resourceName}, func(_ string, r *logsv1.InitRecord, _ bool) {
 		r.Logs = append(r.Logs, &logsv1.InitLog{
-			Event:     logsv1.InitEvent_INIT_EVENT_FAILED,
-			Context:   operator,
+			Event:      logsv1.InitEvent_INIT_EVENT_FAILED,
+			Context:    operator.Context,
+			OperatorId: operator.ID,
+			Timestamp:  time.Now().UnixNano(),
+		})
+	})
+}
+
+// Reset init state of the resource by admin, so that next try performs init again.
+func (c *initController) reset(resourceName string) error {
+	select {
+	case <-c._closing:
+		return errClosing
+	default:
+	}
+
+	v, found := c._resources.Load(resourceName)
+	if !found {
+		return errResourceNotFound
+	}
+	v.(*ctl.InitCtl).Reset()
+
+	return c._store.Put([]string{resourceName}, func(_ string, r *logsv1.InitRecord, _ bool) {
+		r.Logs = append(r.Logs, &logsv1.InitLog{
+			Event:     logsv1.InitEvent_INIT_EVENT_RESET,
 			Timestamp: time.Now().UnixNano(),
+			Admin:     true,
 		})
 	})
 }
 
+var initStates = map[ctl.InitState]resource_mapv1.InitState{
+	ctl.InitStateNotStarted: resource_mapv1.InitState_INIT_STATE_NOT_STARTED,
+	ctl.InitStateInProgress: resource_mapv1.InitState_INIT_STATE_IN_PROGRESS,
+	ctl.InitStateCompleted:  resource_mapv1.InitState_INIT_STATE_COMPLETED,
+	ctl.InitStateFailed:     resource_mapv1.InitState_INIT_STATE_FAILED,
+}
+
+// Set init state of each resource to statuses.
+func (c *initController) status(statuses map[string]*resource_mapv1.ResourceStatus) error {
+	var err error
+	c._resources.Range(func(k, v any) bool {
+		name := k.(string)
+		state, operator := v.(*ctl.InitCtl).Status()
+
+		st := resourceStatus(statuses, name)
+		st.InitState = initStates[state]
+		if operator == "" {
+			return true
+		}
+		var r *logsv1.InitRecord
+		r, err = c._store.GetSnapshot(name)
+		if errors.Is(err, logs.ErrRecordNotFound) {
+			err = nil
+			return true
+		} else if err != nil {
+			return false
+		}
+		st.InitContext = collectCallers(r.Logs)[operator]
+		return true
+	})
+	return err
+}
+
+// Get status of the resource in statuses, or create it.
+func resourceStatus(statuses map[string]*resource_mapv1.ResourceStatus, name string) *resource_mapv1.ResourceStatus {
+	st, ok := statuses[name]
+	if !ok {
+		st = &resource_mapv1.ResourceStatus{
+			ResourceName: name,
+			InitState:    resource_mapv1.InitState_INIT_STATE_NOT_STARTED,
+		}
+		statuses[name] = st
+	}
+	return st
+}
+
+// Collect caller contexts in the logs, keyed by the operator.
+func collectCallers[L logs.OperatorMessage](ls []L) map[string]logs.CallerContext {
+	callers := make(map[string]logs.CallerContext, len(ls))
+	for _, l := range ls {
+		callers[logs.OperatorID(l)] = l.GetContext()
+	}
+	return callers
+}
+
 type (
 	// Control acquisition status and persistence.
 	acquireController struct {
@@ -154,10 +247,13 @@ type (
 		_resources sync.Map
 		_closing   <-chan struct{}
 		_multiMu   sync.Mutex
+		_metrics   *metrics
+		_tracer    trace.Tracer
 	}
 
 	resource struct {
 		once  *oncewait.OnceWaiter
+		ready atomic.Bool
 		queue rendezvous.LimitedTermQueue
 		ctl   *ctl.AcquisitionCtl
 	}
@@ -167,14 +263,15 @@ func loadAcquireController(store logs.ResourceRecordStore[logsv1.AcquisitionReco
 	c := &acquireController{
 		_kv:      store,
 		_closing: closing,
+		_tracer:  noopTracer,
 	}
 
-	err := store.ForEach(func(name string, obj *logsv1.AcquisitionRecord) error {
+	err := store.ForEachSnapshot(func(name string, obj *logsv1.AcquisitionRecord) error {
 		acquired := map[string]int64{}
 		b := rendezvous.NewBuilder()
 		// Replay stored acquisitions of the resource.
 		for _, log := range obj.Logs {
-			operator := logs.CallerContext(log.Context).String()
+			operator := logs.OperatorID(log)
 			switch log.Event {
 			case logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING:
 				// Queue as "acquiring".
@@ -187,19 +284,21 @@ func loadAcquireController(store logs.ResourceRecordStore[logsv1.AcquisitionReco
 				acquired[operator] = log.N
 				// Remove already acquired operation from queue.
 				b.Remove(operator)
+			case logsv1.AcquisitionEvent_ACQUISITION_EVENT_CANCELED:
+				// Operator gave up waiting.
+				b.Remove(operator)
 			case logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED:
 				// We assume that acquisition log is already processed.
 				delete(acquired, operator)
 			}
 		}
 		// Set replayed acquireCtl.
-		c._resources.Store(
-			name,
-			&resource{
-				queue: b.Start(acquiringQueueTimeout),
-				ctl:   ctl.NewAcquisitionCtl(obj.Max, acquired),
-			},
-		)
+		r := &resource{
+			queue: b.Start(acquiringQueueTimeout),
+			ctl:   ctl.NewAcquisitionCtl(obj.Max, acquired),
+		}
+		r.ready.Store(true)
+		c._resources.Store(name, r)
 		return nil
 	})
 	if err != nil {
@@ -215,6 +314,7 @@ func (r *resource) init(max int64) *resource {
 		r.once.Do(func() {
 			r.queue = emptyQueue
 			r.ctl = ctl.NewAcquisitionCtl(max, map[string]int64{})
+			r.ready.Store(true)
 		})
 	}
 	return r
@@ -233,7 +333,7 @@ func (r *resource) acquire(ctx context.Context, operator string, exclusive bool)
 	return ch, acquiring
 }
 
-func (c *acquireController) acquire(ctx context.Context, resourceName string, operator logs.CallerContext, max int64, exclusive bool) error {
+func (c *acquireController) acquire(ctx context.Context, resourceName string, operator logs.Operator, max int64, exclusive bool) error {
 	select {
 	case <-c._closing:
 		return errClosing
@@ -246,11 +346,17 @@ func (c *acquireController) acquire(ctx context.Context, resourceName string, op
 	r := v.(*resource).init(max)
 
 	// Start acquisition.
-	acCh, acquiring := r.acquire(ctx, operator.String(), exclusive)
+	acCh, acquiring := r.acquire(ctx, operator.ID, exclusive)
 	// Due to trial of consecutive acquisition, not acquired.
 	if !acquiring {
 		return nil
 	}
+	start := time.Now()
+	c._metrics.acquiring(resourceName)
+	_, span := c._tracer.Start(ctx, "rsmap.server.wait", trace.WithAttributes(
+		resourceAttr(resourceName),
+		modeAttr(exclusive),
+	))
 
 	// Append log "acquiring".
 	err := c._kv.Put([]string{resourceName}, func(_ string, r *logsv1.AcquisitionRecord, update bool) {
@@ -259,35 +365,127 @@ func (c *acquireController) acquire(ctx context.Context, resourceName string, op
 			r.Max = max
 		}
 		r.Logs = append(r.Logs, &logsv1.AcquisitionLog{
-			Event:     logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
-			Context:   operator,
-			Timestamp: time.Now().UnixNano(),
+			Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
+			Context:    operator.Context,
+			OperatorId: operator.ID,
+			Timestamp:  time.Now().UnixNano(),
+			Exclusive:  proto.Bool(exclusive),
 		})
 	})
 	if err != nil {
+		endSpan(span, err)
 		return err
 	}
 
 	var result ctl.AcquisitionResult
 	select {
 	case <-c._closing:
+		c._metrics.acquired(resourceName, operator.ID, exclusive, start, false)
+		endSpan(span, errClosing)
 		return errClosing
 	case result = <-acCh:
+		c._metrics.acquired(resourceName, operator.ID, exclusive, start, result.Err == nil)
+		endSpan(span, result.Err)
 		if result.Err != nil {
-			return result.Err
+			return errors.Join(result.Err, c.cancel(resourceName, operator, exclusive))
 		}
 	}
 
 	return c._kv.Put([]string{resourceName}, func(_ string, r *logsv1.AcquisitionRecord, update bool) {
 		r.Logs = append(r.Logs, &logsv1.AcquisitionLog{
-			Event:     logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED,
-			N:         result.Acquired,
-			Context:   operator,
-			Timestamp: time.Now().UnixNano(),
+			Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED,
+			N:          result.Acquired,
+			Context:    operator.Context,
+			OperatorId: operator.ID,
+			Timestamp:  time.Now().UnixNano(),
+			Exclusive:  proto.Bool(exclusive),
 		})
 	})
 }
 
+// Append log "canceled", so that the operator giving up waiting is not regarded as waiting anymore.
+// When the server is closing, the log is not appended, because the operator retries on the next server.
+func (c *acquireController) cancel(resourceName string, operator logs.Operator, exclusive bool) error {
+	return c._kv.Put([]string{resourceName}, func(_ string, r *logsv1.AcquisitionRecord, _ bool) {
+		r.Logs = append(r.Logs, &logsv1.AcquisitionLog{
+			Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_CANCELED,
+			Context:    operator.Context,
+			OperatorId: operator.ID,
+			Timestamp:  time.Now().UnixNano(),
+			Exclusive:  proto.Bool(exclusive),
+		})
+	})
+}
+
+// Report whether any resource is being initialized.
+func (c *initController) initializing() bool {
+	var initializing bool
+	c._resources.Range(func(_, v any) bool {
+		state, _ := v.(*ctl.InitCtl).Status()
+		initializing = state == ctl.InitStateInProgress
+		return !initializing
+	})
+	return initializing
+}
+
+// Report whether any resource is acquired or being acquired.
+func (c *acquireController) holding() bool {
+	var holding bool
+	c._resources.Range(func(_, v any) bool {
+		r := v.(*resource)
+		if !r.ready.Load() || r.ctl.Operators() > 0 {
+			holding = true // Resource under initialization is also treated as holding.
+		}
+		return !holding
+	})
+	return holding
+}
+
+// Set holders and waiters of each resource to statuses.
+// Operators whose "acquiring" log is not written yet are omitted.
+func (c *acquireController) status(statuses map[string]*resource_mapv1.ResourceStatus) error {
+	var err error
+	c._resources.Range(func(k, v any) bool {
+		name := k.(string)
+		r := v.(*resource)
+		if !r.ready.Load() {
+			return true
+		}
+		acquisition := r.ctl.Status()
+
+		var record *logsv1.AcquisitionRecord
+		record, err = c._kv.GetSnapshot(name)
+		if errors.Is(err, logs.ErrRecordNotFound) {
+			err = nil
+			return true
+		} else if err != nil {
+			return false
+		}
+		callers := collectCallers(record.Logs)
+		convert := func(acquisitions []ctl.Acquisition) []*resource_mapv1.AcquisitionStatus {
+			var converted []*resource_mapv1.AcquisitionStatus
+			for _, a := range acquisitions {
+				caller, ok := callers[a.Operator]
+				if !ok {
+					continue
+				}
+				converted = append(converted, &resource_mapv1.AcquisitionStatus{
+					Context: caller,
+					N:       a.N,
+				})
+			}
+			return converted
+		}
+
+		st := resourceStatus(statuses, name)
+		st.Max = acquisition.Max
+		st.Holders = convert(acquisition.Holders)
+		st.Waiters = convert(acquisition.Waiters)
+		return true
+	})
+	return err
+}
+
 func (c *acquireController) acquireMulti(ctx context.Context, resources []*resource_mapv1.AcquireMultiEntry) error {
 	select {
 	case <-c._closing:
@@ -298,6 +496,8 @@ func (c *acquireController) acquireMulti(ctx context.Context, resources []*resou
 	type acquiringEntry struct {
 		entry    *resource_mapv1.AcquireMultiEntry
 		acquired <-chan ctl.AcquisitionResult
+		start    time.Time
+		span     trace.Span
 	}
 	identifiers := make([]string, 0, len(resources))
 	entries := make(map[string]acquiringEntry, len(resources))
@@ -311,14 +511,21 @@ func (c *acquireController) acquireMulti(ctx context.Context, resources []*resou
 		r := v.(*resource).init(entry.MaxParallelism)
 
 		// Start acquisition.
-		acCh, acquiring := r.acquire(ctx, logs.CallerContext(entry.Context).String(), entry.Exclusive)
+		acCh, acquiring := r.acquire(ctx, logs.OperatorID(entry), entry.Exclusive)
 		// Due to trial of consecutive acquisition, not acquired.
 		if acquiring {
 			identifiers = append(identifiers, entry.ResourceName)
+			_, span := c._tracer.Start(ctx, "rsmap.server.wait", trace.WithAttributes(
+				resourceAttr(entry.ResourceName),
+				modeAttr(entry.Exclusive),
+			))
 			entries[entry.ResourceName] = acquiringEntry{
 				entry:    entry,
 				acquired: acCh,
+				start:    time.Now(),
+				span:     span,
 			}
+			c._metrics.acquiring(entry.ResourceName)
 		}
 	}
 	c._multiMu.Unlock()
@@ -336,12 +543,17 @@ func (c *acquireController) acquireMulti(ctx context.Context, resources []*resou
 			r.Max = e.entry.MaxParallelism
 		}
 		r.Logs = append(r.Logs, &logsv1.AcquisitionLog{
-			Event:     logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
-			Context:   e.entry.Context,
-			Timestamp: ts,
+			Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
+			Context:    e.entry.Context,
+			OperatorId: e.entry.OperatorId,
+			Timestamp:  ts,
+			Exclusive:  proto.Bool(e.entry.Exclusive),
 		})
 	})
 	if err != nil {
+		for _, e := range entries {
+			endSpan(e.span, err)
+		}
 		return err
 	}
 
@@ -350,22 +562,31 @@ func (c *acquireController) acquireMulti(ctx context.Context, resources []*resou
 	for _, entry := range entries {
 		e := entry
 		eg.Go(func() error {
-			var result ctl.AcquisitionResult
+			var (
+				result   ctl.AcquisitionResult
+				operator = logs.OperatorID(e.entry)
+			)
 			select {
 			case <-c._closing:
+				c._metrics.acquired(e.entry.ResourceName, operator, e.entry.Exclusive, e.start, false)
+				endSpan(e.span, errClosing)
 				return errClosing
 			case result = <-e.acquired:
+				c._metrics.acquired(e.entry.ResourceName, operator, e.entry.Exclusive, e.start, result.Err == nil)
+				endSpan(e.span, result.Err)
 				if result.Err != nil {
-					return err
+					return errors.Join(result.Err, c.cancel(e.entry.ResourceName, logs.OperatorOf(e.entry), e.entry.Exclusive))
 				}
 			}
 
 			return c._kv.Put([]string{e.entry.ResourceName}, func(identifier string, r *logsv1.AcquisitionRecord, update bool) {
 				r.Logs = append(r.Logs, &logsv1.AcquisitionLog{
-					Event:     logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED,
-					N:         result.Acquired,
-					Context:   e.entry.Context,
-					Timestamp: time.Now().UnixNano(),
+					Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED,
+					N:          result.Acquired,
+					Context:    e.entry.Context,
+					OperatorId: e.entry.OperatorId,
+					Timestamp:  time.Now().UnixNano(),
+					Exclusive:  proto.Bool(e.entry.Exclusive),
 				})
 			})
 		})
@@ -374,7 +595,7 @@ func (c *acquireController) acquireMulti(ctx context.Context, resources []*resou
 	return eg.Wait()
 }
 
-func (c *acquireController) release(resourceName string, operator logs.CallerContext) error {
+func (c *acquireController) release(resourceName string, operator logs.Operator) error {
 	select {
 	case <-c._closing:
 		return errClosing
@@ -386,7 +607,7 @@ func (c *acquireController) release(resourceName string, operator logs.CallerCon
 		// If the resource not found, return without error.
 		return nil
 	}
-	op := operator.String()
+	op := operator.ID
 	r := v.(*resource)
 	if !r.ctl.Acquired(op) {
 		// If not acquired, return without error.
@@ -395,10 +616,11 @@ func (c *acquireController) release(resourceName string, operator logs.CallerCon
 
 	err := c._kv.Put([]string{resourceName}, func(_ string, r *logsv1.AcquisitionRecord, _ bool) {
 		r.Logs = append(r.Logs, &logsv1.AcquisitionLog{
-			Event:     logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED,
-			N:         0,
-			Context:   operator,
-			Timestamp: time.Now().UnixNano(),
+			Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED,
+			N:          0,
+			Context:    operator.Context,
+			OperatorId: operator.ID,
+			Timestamp:  time.Now().UnixNano(),
 		})
 	})
 	if err != nil {
@@ -406,9 +628,66 @@ func (c *acquireController) release(resourceName string, operator logs.CallerCon
 	}
 
 	r.ctl.Release(op)
+	c._metrics.released(resourceName, op)
 	return nil
 }
 
+// Release the lock held by the operator forcibly by admin.
+// The operator is specified by the hash of the last caller, the whole chain of hashes, or the operator ID.
+func (c *acquireController) forceRelease(resourceName, callerHash string) (logs.CallerContext, error) {
+	select {
+	case <-c._closing:
+		return nil, errClosing
+	default:
+	}
+
+	v, found := c._resources.Load(resourceName)
+	if !found {
+		return nil, errResourceNotFound
+	}
+	r := v.(*resource)
+	if !r.ready.Load() {
+		return nil, errHolderNotFound
+	}
+	record, err := c._kv.GetSnapshot(resourceName)
+	if errors.Is(err, logs.ErrRecordNotFound) {
+		return nil, errHolderNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	callers := collectCallers(record.Logs)
+
+	var operator logs.Operator
+	for _, h := range r.ctl.Status().Holders {
+		caller, ok := callers[h.Operator]
+		if ok && len(caller) > 0 &&
+			(caller[len(caller)-1].Hash == callerHash || caller.ShortString() == callerHash || h.Operator == callerHash) {
+			operator = logs.Operator{ID: h.Operator, Context: caller}
+			break
+		}
+	}
+	if operator.ID == "" {
+		return nil, errHolderNotFound
+	}
+
+	op := operator.ID
+	err = c._kv.Put([]string{resourceName}, func(_ string, r *logsv1.AcquisitionRecord, _ bool) {
+		r.Logs = append(r.Logs, &logsv1.AcquisitionLog{
+			Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED,
+			Context:    operator.Context,
+			OperatorId: operator.ID,
+			Timestamp:  time.Now().UnixNano(),
+			Admin:      true,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.ctl.Release(op)
+	c._metrics.released(resourceName, op)
+	return operator.Context, nil
+}
+
 func (c *acquireController) releaseMulti(resources []*resource_mapv1.ReleaseMultiEntry) error {
 	select {
 	case <-c._closing:
@@ -426,7 +705,7 @@ func (c *acquireController) releaseMulti(resources []*resource_mapv1.ReleaseMult
 			continue
 		}
 		r := v.(*resource)
-		op := logs.CallerContext(entry.Context).String()
+		op := logs.OperatorID(entry)
 		if !r.ctl.Acquired(op) {
 			// If not acquired, skip it.
 			continue
@@ -435,7 +714,10 @@ func (c *acquireController) releaseMulti(resources []*resource_mapv1.ReleaseMult
 		identifiers = append(identifiers, entry.ResourceName)
 
 		// Release after log write.
-		defer r.ctl.Release(op)
+		defer func(resourceName string) {
+			r.ctl.Release(op)
+			c._metrics.released(resourceName, op)
+		}(entry.ResourceName)
 	}
 
 	ts := time.Now().UnixNano()
@@ -443,10 +725,11 @@ func (c *acquireController) releaseMulti(resources []*resource_mapv1.ReleaseMult
 		e := entries[identifier]
 
 		r.Logs = append(r.Logs, &logsv1.AcquisitionLog{
-			Event:     logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED,
-			N:         0,
-			Context:   e.Context,
-			Timestamp: ts,
+			Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED,
+			N:          0,
+			Context:    e.Context,
+			OperatorId: e.OperatorId,
+			Timestamp:  ts,
 		})
 	})
 }
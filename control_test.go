@@ -13,6 +13,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"go.etcd.io/bbolt"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/protobuf/proto"
 	"gotest.tools/v3/assert"
 
 	logsv1 "github.com/daichitakahashi/rsmap/internal/proto/logs/v1"
@@ -39,6 +40,17 @@ func openDB(t *testing.T) *bbolt.DB {
 	return db
 }
 
+func memoryBackend(t *testing.T) logs.Backend {
+	t.Helper()
+
+	b, err := logs.OpenMemoryBackend(background, t.Name())
+	assert.NilError(t, err)
+	t.Cleanup(func() {
+		_ = b.Close()
+	})
+	return b
+}
+
 var (
 	callerAlice = logs.CallerContext{
 		{
@@ -76,6 +88,10 @@ var (
 			Hash: "349757b7",
 		},
 	}
+
+	operatorAlice   = logs.Operator{ID: "alice", Context: callerAlice}
+	operatorBob     = logs.Operator{ID: "bob", Context: callerBob}
+	operatorCharlie = logs.Operator{ID: "charlie", Context: callerCharlie}
 )
 
 var protoCmpOpts = []cmp.Option{
@@ -107,7 +123,7 @@ func TestInitController(t *testing.T) {
 		assert.NilError(t, err)
 
 		// Start init by Alice.
-		try, err := ctl.tryInit(background, "treasure", callerAlice)
+		try, err := ctl.tryInit(background, "treasure", operatorAlice)
 		assert.NilError(t, err)
 		assert.Assert(t, try)
 
@@ -117,7 +133,7 @@ func TestInitController(t *testing.T) {
 			err error
 		}
 		bobsTry := asyncResult(func() tryInitResult {
-			try, err := ctl.tryInit(background, "treasure", callerBob)
+			try, err := ctl.tryInit(background, "treasure", operatorBob)
 			return tryInitResult{
 				try: try,
 				err: err,
@@ -137,7 +153,7 @@ func TestInitController(t *testing.T) {
 
 		// Complete init by Alice.
 		assert.NilError(t,
-			ctl.complete("treasure", callerAlice),
+			ctl.complete("treasure", operatorAlice),
 		)
 
 		// Check Bob's result again.
@@ -152,11 +168,13 @@ func TestInitController(t *testing.T) {
 		assert.DeepEqual(t, r, &logsv1.InitRecord{
 			Logs: []*logsv1.InitLog{
 				{
-					Event:   logsv1.InitEvent_INIT_EVENT_STARTED,
-					Context: callerAlice,
+					Event:      logsv1.InitEvent_INIT_EVENT_STARTED,
+					Context:    callerAlice,
+					OperatorId: "alice",
 				}, {
-					Event:   logsv1.InitEvent_INIT_EVENT_COMPLETED,
-					Context: callerAlice,
+					Event:      logsv1.InitEvent_INIT_EVENT_COMPLETED,
+					Context:    callerAlice,
+					OperatorId: "alice",
 				},
 			},
 		}, protoCmpOpts...)
@@ -173,12 +191,12 @@ func TestInitController(t *testing.T) {
 		assert.NilError(t, err)
 
 		// Start init by Alice.
-		try, err := ctl.tryInit(background, "treasure", callerAlice)
+		try, err := ctl.tryInit(background, "treasure", operatorAlice)
 		assert.NilError(t, err)
 		assert.Assert(t, try)
 
 		// Consecutive init.
-		secondTry, err := ctl.tryInit(background, "treasure", callerAlice)
+		secondTry, err := ctl.tryInit(background, "treasure", operatorAlice)
 		assert.NilError(t, err)
 		assert.Equal(t, try, secondTry)
 
@@ -188,8 +206,9 @@ func TestInitController(t *testing.T) {
 		assert.DeepEqual(t, r, &logsv1.InitRecord{
 			Logs: []*logsv1.InitLog{
 				{
-					Event:   logsv1.InitEvent_INIT_EVENT_STARTED,
-					Context: callerAlice,
+					Event:      logsv1.InitEvent_INIT_EVENT_STARTED,
+					Context:    callerAlice,
+					OperatorId: "alice",
 				},
 			},
 		}, protoCmpOpts...)
@@ -215,7 +234,7 @@ func TestInitController(t *testing.T) {
 			prepared <- struct{}{}
 			<-started
 
-			try, err := ctl.tryInit(background, "treasure", callerAlice)
+			try, err := ctl.tryInit(background, "treasure", operatorAlice)
 			if err != nil {
 				return err
 			}
@@ -223,7 +242,7 @@ func TestInitController(t *testing.T) {
 				return errors.New("try must be true")
 			}
 
-			return ctl.fail("treasure", callerAlice)
+			return ctl.fail("treasure", operatorAlice)
 		})
 
 		eg.Go(func() error {
@@ -231,7 +250,7 @@ func TestInitController(t *testing.T) {
 			<-started
 			time.Sleep(time.Millisecond * 200)
 
-			try, err := ctl.tryInit(background, "treasure", callerBob)
+			try, err := ctl.tryInit(background, "treasure", operatorBob)
 			if err != nil {
 				return err
 			}
@@ -247,7 +266,7 @@ func TestInitController(t *testing.T) {
 		assert.NilError(t, eg.Wait())
 
 		assert.NilError(t,
-			ctl.complete("treasure", callerBob),
+			ctl.complete("treasure", operatorBob),
 		)
 	})
 
@@ -262,9 +281,10 @@ func TestInitController(t *testing.T) {
 		assert.NilError(t,
 			store.Put([]string{"treasure"}, func(_ string, r *logsv1.InitRecord, _ bool) {
 				r.Logs = append(r.Logs, &logsv1.InitLog{
-					Event:     logsv1.InitEvent_INIT_EVENT_STARTED,
-					Context:   callerAlice,
-					Timestamp: time.Now().UnixNano(),
+					Event:      logsv1.InitEvent_INIT_EVENT_STARTED,
+					Context:    callerAlice,
+					OperatorId: "alice",
+					Timestamp:  time.Now().UnixNano(),
 				})
 			}),
 		)
@@ -275,15 +295,15 @@ func TestInitController(t *testing.T) {
 		// Bob's try, timed out.
 		timedOut, cancel := context.WithDeadline(background, time.Now().Add(time.Millisecond))
 		defer cancel()
-		try, err := ctl.tryInit(timedOut, "treasure", callerBob)
+		try, err := ctl.tryInit(timedOut, "treasure", operatorBob)
 		assert.ErrorIs(t, err, context.DeadlineExceeded)
 		assert.Assert(t, !try)
 
 		// Alice finishes init operation.
-		assert.NilError(t, ctl.complete("treasure", callerAlice))
+		assert.NilError(t, ctl.complete("treasure", operatorAlice))
 
 		// Bob receives completion of init.
-		try, err = ctl.tryInit(background, "treasure", callerBob)
+		try, err = ctl.tryInit(background, "treasure", operatorBob)
 		assert.NilError(t, err)
 		assert.Assert(t, !try)
 
@@ -293,11 +313,13 @@ func TestInitController(t *testing.T) {
 		assert.DeepEqual(t, r, &logsv1.InitRecord{
 			Logs: []*logsv1.InitLog{
 				{
-					Event:   logsv1.InitEvent_INIT_EVENT_STARTED,
-					Context: callerAlice,
+					Event:      logsv1.InitEvent_INIT_EVENT_STARTED,
+					Context:    callerAlice,
+					OperatorId: "alice",
 				}, {
-					Event:   logsv1.InitEvent_INIT_EVENT_COMPLETED,
-					Context: callerAlice,
+					Event:      logsv1.InitEvent_INIT_EVENT_COMPLETED,
+					Context:    callerAlice,
+					OperatorId: "alice",
 				},
 			},
 		}, protoCmpOpts...)
@@ -315,13 +337,15 @@ func TestInitController(t *testing.T) {
 			store.Put([]string{"treasure"}, func(_ string, r *logsv1.InitRecord, _ bool) {
 				r.Logs = append(r.Logs, []*logsv1.InitLog{
 					{
-						Event:     logsv1.InitEvent_INIT_EVENT_STARTED,
-						Context:   callerAlice,
-						Timestamp: time.Now().UnixNano(),
+						Event:      logsv1.InitEvent_INIT_EVENT_STARTED,
+						Context:    callerAlice,
+						OperatorId: "alice",
+						Timestamp:  time.Now().UnixNano(),
 					}, {
-						Event:     logsv1.InitEvent_INIT_EVENT_COMPLETED,
-						Context:   callerAlice,
-						Timestamp: time.Now().UnixNano(),
+						Event:      logsv1.InitEvent_INIT_EVENT_COMPLETED,
+						Context:    callerAlice,
+						OperatorId: "alice",
+						Timestamp:  time.Now().UnixNano(),
 					}}...)
 			}),
 		)
@@ -330,7 +354,7 @@ func TestInitController(t *testing.T) {
 		assert.NilError(t, err)
 
 		// Bob tries init, but already completed by Alice.
-		try, err := ctl.tryInit(background, "treasure", callerBob)
+		try, err := ctl.tryInit(background, "treasure", operatorBob)
 		assert.NilError(t, err)
 		assert.Assert(t, !try)
 	})
@@ -346,18 +370,18 @@ func TestInitController(t *testing.T) {
 		assert.NilError(t, err)
 
 		// Setup situation that init has failed.
-		_, err = ctl.tryInit(background, "treasure", callerAlice)
+		_, err = ctl.tryInit(background, "treasure", operatorAlice)
 		assert.NilError(t, err)
-		assert.NilError(t, ctl.fail("treasure", callerAlice))
+		assert.NilError(t, ctl.fail("treasure", operatorAlice))
 
 		replayed, err := loadInitController(store, nil)
 		assert.NilError(t, err)
 
 		// Bob retries.
-		try, err := replayed.tryInit(background, "treasure", callerBob)
+		try, err := replayed.tryInit(background, "treasure", operatorBob)
 		assert.NilError(t, err)
 		assert.Assert(t, try)
-		assert.NilError(t, replayed.complete("treasure", callerBob))
+		assert.NilError(t, replayed.complete("treasure", operatorBob))
 	})
 
 	t.Run("'Closing' interrupts acquisition", func(t *testing.T) {
@@ -378,7 +402,7 @@ func TestInitController(t *testing.T) {
 		assert.NilError(t, err)
 
 		// Try init by Alice.
-		try, err := ctl.tryInit(background, "treasure", callerAlice)
+		try, err := ctl.tryInit(background, "treasure", operatorAlice)
 		assert.NilError(t, err)
 		assert.Assert(t, try)
 
@@ -389,7 +413,7 @@ func TestInitController(t *testing.T) {
 
 			// Alice reports success of init after 200ms.
 			time.Sleep(time.Millisecond * 200)
-			return ctl.complete("treasure", callerAlice)
+			return ctl.complete("treasure", operatorAlice)
 		})
 
 		// "closing" occurred while Bob tries to init.
@@ -406,14 +430,14 @@ func TestInitController(t *testing.T) {
 		close(begin)
 
 		// Bob's try will be canceled.
-		try, err = ctl.tryInit(background, "treasure", callerBob)
+		try, err = ctl.tryInit(background, "treasure", operatorBob)
 		assert.ErrorIs(t, err, errClosing)
 		assert.Assert(t, !try)
 
 		// Completion report by Alice also fails.
 		assert.ErrorIs(t, eg.Wait(), errClosing)
 		// Failure report fails too.
-		assert.ErrorIs(t, ctl.fail("treasure", callerAlice), errClosing)
+		assert.ErrorIs(t, ctl.fail("treasure", operatorAlice), errClosing)
 
 		// Check stored logs.
 		r, err := store.Get("treasure")
@@ -421,8 +445,9 @@ func TestInitController(t *testing.T) {
 		assert.DeepEqual(t, r, &logsv1.InitRecord{
 			Logs: []*logsv1.InitLog{
 				{
-					Event:   logsv1.InitEvent_INIT_EVENT_STARTED,
-					Context: callerAlice,
+					Event:      logsv1.InitEvent_INIT_EVENT_STARTED,
+					Context:    callerAlice,
+					OperatorId: "alice",
 				},
 			},
 		}, protoCmpOpts...)
@@ -444,34 +469,34 @@ func TestAcquireController(t *testing.T) {
 
 		// Acquire shared lock by Alice and Bob.
 		assert.NilError(t,
-			ctl.acquire(background, "treasure", callerAlice, 100, false),
+			ctl.acquire(background, "treasure", operatorAlice, 100, false),
 		)
 		assert.NilError(t,
-			ctl.acquire(background, "treasure", callerBob, 100, false),
+			ctl.acquire(background, "treasure", operatorBob, 100, false),
 		)
 
 		// Acquisition of exclusive lock by Charlie should be failed.
 		timedOut, cancel := context.WithTimeout(background, time.Millisecond*100)
 		defer cancel()
 		assert.ErrorIs(t,
-			ctl.acquire(timedOut, "treasure", callerCharlie, 100, true),
+			ctl.acquire(timedOut, "treasure", operatorCharlie, 100, true),
 			context.DeadlineExceeded,
 		)
 
 		// Release shared locks.
 		assert.NilError(t,
-			ctl.release("treasure", callerAlice),
+			ctl.release("treasure", operatorAlice),
 		)
 		assert.NilError(t,
-			ctl.release("treasure", callerBob),
+			ctl.release("treasure", operatorBob),
 		)
 
 		// Retry of Charlie.
 		assert.NilError(t,
-			ctl.acquire(background, "treasure", callerCharlie, 100, true),
+			ctl.acquire(background, "treasure", operatorCharlie, 100, true),
 		)
 		assert.NilError(t,
-			ctl.release("treasure", callerCharlie),
+			ctl.release("treasure", operatorCharlie),
 		)
 
 		// Check stored logs.
@@ -481,38 +506,60 @@ func TestAcquireController(t *testing.T) {
 			Max: 100,
 			Logs: []*logsv1.AcquisitionLog{
 				{
-					Event:   logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
-					Context: callerAlice,
+					Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
+					Context:    callerAlice,
+					OperatorId: "alice",
+					Exclusive:  proto.Bool(false),
+				}, {
+					Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED,
+					N:          1,
+					Context:    callerAlice,
+					OperatorId: "alice",
+					Exclusive:  proto.Bool(false),
 				}, {
-					Event:   logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED,
-					N:       1,
-					Context: callerAlice,
+					Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
+					Context:    callerBob,
+					OperatorId: "bob",
+					Exclusive:  proto.Bool(false),
 				}, {
-					Event:   logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
-					Context: callerBob,
+					Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED,
+					N:          1,
+					Context:    callerBob,
+					OperatorId: "bob",
+					Exclusive:  proto.Bool(false),
 				}, {
-					Event:   logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED,
-					N:       1,
-					Context: callerBob,
+					Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
+					Context:    callerCharlie,
+					OperatorId: "charlie",
+					Exclusive:  proto.Bool(true),
 				}, {
-					Event:   logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
-					Context: callerCharlie,
+					Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_CANCELED,
+					Context:    callerCharlie,
+					OperatorId: "charlie",
+					Exclusive:  proto.Bool(true),
 				}, {
-					Event:   logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED,
-					Context: callerAlice,
+					Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED,
+					Context:    callerAlice,
+					OperatorId: "alice",
 				}, {
-					Event:   logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED,
-					Context: callerBob,
+					Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED,
+					Context:    callerBob,
+					OperatorId: "bob",
 				}, {
-					Event:   logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
-					Context: callerCharlie,
+					Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
+					Context:    callerCharlie,
+					OperatorId: "charlie",
+					Exclusive:  proto.Bool(true),
 				}, {
-					Event:   logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED,
-					N:       100,
-					Context: callerCharlie,
+					Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED,
+					N:          100,
+					Context:    callerCharlie,
+					OperatorId: "charlie",
+					Exclusive:  proto.Bool(true),
 				}, {
-					Event:   logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED,
-					Context: callerCharlie,
+					Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED,
+					Context:    callerCharlie,
+					OperatorId: "charlie",
 				},
 			},
 		}, protoCmpOpts...)
@@ -530,20 +577,20 @@ func TestAcquireController(t *testing.T) {
 
 		// First acquisition.
 		assert.NilError(t,
-			ctl.acquire(background, "treasure", callerAlice, 100, true),
+			ctl.acquire(background, "treasure", operatorAlice, 100, true),
 		)
 		// Second acquisition without error(not acquired actually).
 		assert.NilError(t,
-			ctl.acquire(background, "treasure", callerAlice, 100, true),
+			ctl.acquire(background, "treasure", operatorAlice, 100, true),
 		)
 
 		// First release.
 		assert.NilError(t,
-			ctl.release("treasure", callerAlice),
+			ctl.release("treasure", operatorAlice),
 		)
 		// Second release without error(already released).
 		assert.NilError(t,
-			ctl.release("treasure", callerAlice),
+			ctl.release("treasure", operatorAlice),
 		)
 
 		// Check stored logs.
@@ -553,16 +600,21 @@ func TestAcquireController(t *testing.T) {
 			Max: 100,
 			Logs: []*logsv1.AcquisitionLog{
 				{
-					Event:   logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
-					Context: callerAlice,
+					Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
+					Context:    callerAlice,
+					OperatorId: "alice",
+					Exclusive:  proto.Bool(true),
 				}, {
-					Event:   logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED,
-					N:       100,
-					Context: callerAlice,
+					Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED,
+					N:          100,
+					Context:    callerAlice,
+					OperatorId: "alice",
+					Exclusive:  proto.Bool(true),
 				}, {
-					Event:   logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED,
-					N:       0,
-					Context: callerAlice,
+					Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED,
+					N:          0,
+					Context:    callerAlice,
+					OperatorId: "alice",
 				},
 			},
 		}, protoCmpOpts...)
@@ -581,27 +633,32 @@ func TestAcquireController(t *testing.T) {
 				r.Max = 10
 				r.Logs = append(r.Logs, []*logsv1.AcquisitionLog{
 					{
-						Event:     logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
-						Context:   callerAlice,
-						Timestamp: time.Now().UnixNano(),
+						Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
+						Context:    callerAlice,
+						OperatorId: "alice",
+						Timestamp:  time.Now().UnixNano(),
 					}, {
-						Event:     logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED,
-						N:         10,
-						Context:   callerAlice,
-						Timestamp: time.Now().UnixNano(),
+						Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED,
+						N:          10,
+						Context:    callerAlice,
+						OperatorId: "alice",
+						Timestamp:  time.Now().UnixNano(),
 					}, {
-						Event:     logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED,
-						Context:   callerAlice,
-						Timestamp: time.Now().UnixNano(),
+						Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED,
+						Context:    callerAlice,
+						OperatorId: "alice",
+						Timestamp:  time.Now().UnixNano(),
 					}, {
-						Event:     logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
-						Context:   callerAlice,
-						Timestamp: time.Now().UnixNano(),
+						Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
+						Context:    callerAlice,
+						OperatorId: "alice",
+						Timestamp:  time.Now().UnixNano(),
 					}, {
-						Event:     logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED,
-						N:         1,
-						Context:   callerAlice,
-						Timestamp: 0,
+						Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED,
+						N:          1,
+						Context:    callerAlice,
+						OperatorId: "alice",
+						Timestamp:  0,
 					},
 				}...)
 			}),
@@ -611,14 +668,16 @@ func TestAcquireController(t *testing.T) {
 				r.Max = 200
 				r.Logs = append(r.Logs, []*logsv1.AcquisitionLog{
 					{
-						Event:     logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
-						Context:   callerAlice,
-						Timestamp: time.Now().UnixNano(),
+						Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
+						Context:    callerAlice,
+						OperatorId: "alice",
+						Timestamp:  time.Now().UnixNano(),
 					}, {
-						Event:     logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED,
-						N:         200,
-						Context:   callerAlice,
-						Timestamp: time.Now().UnixNano(),
+						Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED,
+						N:          200,
+						Context:    callerAlice,
+						OperatorId: "alice",
+						Timestamp:  time.Now().UnixNano(),
 					},
 				}...)
 			}),
@@ -632,19 +691,19 @@ func TestAcquireController(t *testing.T) {
 
 			// Alice's consecutive acquisition is ignored.
 			assert.NilError(t,
-				ctl.acquire(background, "treasure", callerAlice, 10, false),
+				ctl.acquire(background, "treasure", operatorAlice, 10, false),
 			)
 
 			// Bob's trial to acquire exclusive lock will be timed out.
 			timedOut, cancel := context.WithTimeout(background, time.Millisecond*100)
 			defer cancel()
 			assert.ErrorIs(t,
-				ctl.acquire(timedOut, "treasure", callerBob, 10, true),
+				ctl.acquire(timedOut, "treasure", operatorBob, 10, true),
 				context.DeadlineExceeded,
 			)
 			// But shared lock can be acquired.
 			assert.NilError(t,
-				ctl.acquire(background, "treasure", callerBob, 10, false),
+				ctl.acquire(background, "treasure", operatorBob, 10, false),
 			)
 
 			// Check stored logs.
@@ -654,32 +713,48 @@ func TestAcquireController(t *testing.T) {
 				Max: 10,
 				Logs: []*logsv1.AcquisitionLog{
 					{
-						Event:   logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
-						Context: callerAlice,
+						Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
+						Context:    callerAlice,
+						OperatorId: "alice",
 					}, {
-						Event:   logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED,
-						N:       10,
-						Context: callerAlice,
+						Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED,
+						N:          10,
+						Context:    callerAlice,
+						OperatorId: "alice",
 					}, {
-						Event:   logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED,
-						Context: callerAlice,
+						Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED,
+						Context:    callerAlice,
+						OperatorId: "alice",
 					}, {
-						Event:   logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
-						Context: callerAlice,
+						Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
+						Context:    callerAlice,
+						OperatorId: "alice",
 					}, {
-						Event:   logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED,
-						N:       1,
-						Context: callerAlice,
+						Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED,
+						N:          1,
+						Context:    callerAlice,
+						OperatorId: "alice",
 					}, {
-						Event:   logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
-						Context: callerBob,
+						Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
+						Context:    callerBob,
+						OperatorId: "bob",
+						Exclusive:  proto.Bool(true),
 					}, {
-						Event:   logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
-						Context: callerBob,
+						Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_CANCELED,
+						Context:    callerBob,
+						OperatorId: "bob",
+						Exclusive:  proto.Bool(true),
 					}, {
-						Event:   logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED,
-						N:       1,
-						Context: callerBob,
+						Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
+						Context:    callerBob,
+						OperatorId: "bob",
+						Exclusive:  proto.Bool(false),
+					}, {
+						Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED,
+						N:          1,
+						Context:    callerBob,
+						OperatorId: "bob",
+						Exclusive:  proto.Bool(false),
 					},
 				},
 			}, protoCmpOpts...)
@@ -692,18 +767,18 @@ func TestAcquireController(t *testing.T) {
 			timedOut, cancel := context.WithTimeout(background, time.Millisecond*100)
 			defer cancel()
 			assert.ErrorIs(t,
-				ctl.acquire(timedOut, "precious", callerBob, 200, false),
+				ctl.acquire(timedOut, "precious", operatorBob, 200, false),
 				context.DeadlineExceeded,
 			)
 
 			// Release by Alice.
 			assert.NilError(t,
-				ctl.release("precious", callerAlice),
+				ctl.release("precious", operatorAlice),
 			)
 
 			// Bob's acquisition succeeds now.
 			assert.NilError(t,
-				ctl.acquire(background, "precious", callerBob, 200, false),
+				ctl.acquire(background, "precious", operatorBob, 200, false),
 			)
 
 			// Check stored logs.
@@ -713,25 +788,39 @@ func TestAcquireController(t *testing.T) {
 				Max: 200,
 				Logs: []*logsv1.AcquisitionLog{
 					{
-						Event:   logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
-						Context: callerAlice,
+						Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
+						Context:    callerAlice,
+						OperatorId: "alice",
+					}, {
+						Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED,
+						N:          200,
+						Context:    callerAlice,
+						OperatorId: "alice",
 					}, {
-						Event:   logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED,
-						N:       200,
-						Context: callerAlice,
+						Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
+						Context:    callerBob,
+						OperatorId: "bob",
+						Exclusive:  proto.Bool(false),
 					}, {
-						Event:   logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
-						Context: callerBob,
+						Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_CANCELED,
+						Context:    callerBob,
+						OperatorId: "bob",
+						Exclusive:  proto.Bool(false),
 					}, {
-						Event:   logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED,
-						Context: callerAlice,
+						Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED,
+						Context:    callerAlice,
+						OperatorId: "alice",
 					}, {
-						Event:   logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
-						Context: callerBob,
+						Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
+						Context:    callerBob,
+						OperatorId: "bob",
+						Exclusive:  proto.Bool(false),
 					}, {
-						Event:   logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED,
-						N:       1,
-						Context: callerBob,
+						Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED,
+						N:          1,
+						Context:    callerBob,
+						OperatorId: "bob",
+						Exclusive:  proto.Bool(false),
 					},
 				},
 			}, protoCmpOpts...)
@@ -756,7 +845,7 @@ func TestAcquireController(t *testing.T) {
 
 		// First acquisition by Alice.
 		assert.NilError(t,
-			ctl.acquire(background, "treasure", callerAlice, 5, true),
+			ctl.acquire(background, "treasure", operatorAlice, 5, true),
 		)
 
 		wg.Add(2)
@@ -766,7 +855,7 @@ func TestAcquireController(t *testing.T) {
 
 			// Alice releases after 200ms.
 			time.Sleep(time.Millisecond * 200)
-			return ctl.release("treasure", callerAlice)
+			return ctl.release("treasure", operatorAlice)
 		})
 
 		// "closing" occurred while Bob tries to acquire.
@@ -783,7 +872,7 @@ func TestAcquireController(t *testing.T) {
 
 		// Bob's try will be canceled.
 		assert.ErrorIs(t,
-			ctl.acquire(background, "treasure", callerBob, 5, true),
+			ctl.acquire(background, "treasure", operatorBob, 5, true),
 			errClosing,
 		)
 
@@ -797,19 +886,70 @@ func TestAcquireController(t *testing.T) {
 			Max: 5,
 			Logs: []*logsv1.AcquisitionLog{
 				{
-					Event:   logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
-					Context: callerAlice,
+					Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
+					Context:    callerAlice,
+					OperatorId: "alice",
+					Exclusive:  proto.Bool(true),
 				}, {
-					Event:   logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED,
-					N:       5,
-					Context: callerAlice,
+					Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED,
+					N:          5,
+					Context:    callerAlice,
+					OperatorId: "alice",
+					Exclusive:  proto.Bool(true),
 				}, {
-					Event:   logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
-					Context: callerBob,
+					Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
+					Context:    callerBob,
+					OperatorId: "bob",
+					Exclusive:  proto.Bool(true),
 				},
 			},
 		}, protoCmpOpts...)
 	})
+
+	t.Run("Operators are distinguished by ID even if the contexts collide", func(t *testing.T) {
+		t.Parallel()
+
+		db := openDB(t)
+		store, err := logs.NewResourceRecordStore[logsv1.AcquisitionRecord](db)
+		assert.NilError(t, err)
+
+		ctl, err := loadAcquireController(store, time.Second, nil)
+		assert.NilError(t, err)
+
+		// Same file, line and hash, but different operators.
+		var (
+			alice = logs.NewOperator(logs.CallerContext{{File: "alice.go", Line: 10, Hash: "f1237f58"}})
+			bob   = logs.NewOperator(logs.CallerContext{{File: "alice.go", Line: 10, Hash: "f1237f58"}})
+		)
+		assert.Equal(t, alice.Context.String(), bob.Context.String())
+
+		assert.NilError(t,
+			ctl.acquire(background, "treasure", alice, 100, true),
+		)
+
+		// Bob is not regarded as the holder.
+		timedOut, cancel := context.WithTimeout(background, time.Millisecond*100)
+		defer cancel()
+		assert.ErrorIs(t,
+			ctl.acquire(timedOut, "treasure", bob, 100, true),
+			context.DeadlineExceeded,
+		)
+
+		// Release by Bob is no-op.
+		assert.NilError(t,
+			ctl.release("treasure", bob),
+		)
+		r, err := store.Get("treasure")
+		assert.NilError(t, err)
+		assert.Equal(t, r.Logs[len(r.Logs)-1].Event, logsv1.AcquisitionEvent_ACQUISITION_EVENT_CANCELED)
+
+		assert.NilError(t,
+			ctl.release("treasure", alice),
+		)
+		assert.NilError(t,
+			ctl.acquire(background, "treasure", bob, 100, true),
+		)
+	})
 }
 
 func TestAcquisitionController_Acquiring(t *testing.T) {
@@ -832,9 +972,10 @@ func TestAcquisitionController_Acquiring(t *testing.T) {
 				r.Max = 20
 				r.Logs = append(r.Logs, []*logsv1.AcquisitionLog{
 					{
-						Event:     logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
-						Context:   callerAlice,
-						Timestamp: time.Now().UnixNano(),
+						Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
+						Context:    callerAlice,
+						OperatorId: "alice",
+						Timestamp:  time.Now().UnixNano(),
 					},
 				}...)
 			}),
@@ -850,12 +991,12 @@ func TestAcquisitionController_Acquiring(t *testing.T) {
 			<-begin
 
 			// Bob tries to acquire immediately.
-			err := ctl.acquire(background, "treasure", callerBob, 20, true)
+			err := ctl.acquire(background, "treasure", operatorBob, 20, true)
 			if err != nil {
 				return err
 			}
 			fmt.Fprintln(out, "bob")
-			return ctl.release("treasure", callerBob)
+			return ctl.release("treasure", operatorBob)
 		})
 		eg.Go(func() error {
 			wg.Done()
@@ -863,12 +1004,12 @@ func TestAcquisitionController_Acquiring(t *testing.T) {
 
 			// After 100ms, Alice tries to acquire.
 			time.Sleep(time.Millisecond * 100)
-			err := ctl.acquire(background, "treasure", callerAlice, 20, true)
+			err := ctl.acquire(background, "treasure", operatorAlice, 20, true)
 			if err != nil {
 				return err
 			}
 			fmt.Fprintln(out, "alice")
-			return ctl.release("treasure", callerAlice)
+			return ctl.release("treasure", operatorAlice)
 		})
 		wg.Wait()
 		close(begin)
@@ -877,6 +1018,43 @@ func TestAcquisitionController_Acquiring(t *testing.T) {
 		assert.DeepEqual(t, out.String(), "alice\nbob\n")
 	})
 
+	t.Run("Canceled operation is not queued", func(t *testing.T) {
+		t.Parallel()
+
+		db := openDB(t)
+		store, err := logs.NewResourceRecordStore[logsv1.AcquisitionRecord](db)
+		assert.NilError(t, err)
+		assert.NilError(t,
+			store.Put([]string{"treasure"}, func(_ string, r *logsv1.AcquisitionRecord, _ bool) {
+				r.Max = 20
+				r.Logs = append(r.Logs, []*logsv1.AcquisitionLog{
+					{
+						Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
+						Context:    callerAlice,
+						OperatorId: "alice",
+						Timestamp:  time.Now().UnixNano(),
+					}, {
+						Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_CANCELED,
+						Context:    callerAlice,
+						OperatorId: "alice",
+						Timestamp:  time.Now().UnixNano(),
+					},
+				}...)
+			}),
+		)
+
+		// The timeout of queue is 1 hour.
+		ctl, err := loadAcquireController(store, time.Hour, nil)
+		assert.NilError(t, err)
+
+		// Bob doesn't wait for Alice.
+		timedOut, cancel := context.WithTimeout(background, time.Second)
+		defer cancel()
+		assert.NilError(t,
+			ctl.acquire(timedOut, "treasure", operatorBob, 20, true),
+		)
+	})
+
 	t.Run("The operation that is not queued completes after timeout", func(t *testing.T) {
 		t.Parallel()
 
@@ -888,9 +1066,10 @@ func TestAcquisitionController_Acquiring(t *testing.T) {
 				r.Max = 20
 				r.Logs = append(r.Logs, []*logsv1.AcquisitionLog{
 					{
-						Event:     logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
-						Context:   callerAlice,
-						Timestamp: time.Now().UnixNano(),
+						Event:      logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
+						Context:    callerAlice,
+						OperatorId: "alice",
+						Timestamp:  time.Now().UnixNano(),
 					},
 				}...)
 			}),
@@ -904,7 +1083,7 @@ func TestAcquisitionController_Acquiring(t *testing.T) {
 
 		// Bob tries to acquire.
 		assert.NilError(t,
-			ctl.acquire(background, "treasure", callerBob, 20, false),
+			ctl.acquire(background, "treasure", operatorBob, 20, false),
 		)
 
 		// Check if blocking has occurred until timeout.
@@ -912,7 +1091,57 @@ func TestAcquisitionController_Acquiring(t *testing.T) {
 		assert.Assert(t, elapsed > time.Millisecond*500, "%s", elapsed)
 
 		assert.NilError(t,
-			ctl.acquire(background, "treasure", callerAlice, 20, false),
+			ctl.acquire(background, "treasure", operatorAlice, 20, false),
 		)
 	})
 }
+
+func TestServerSideMap_MemoryBackend(t *testing.T) {
+	t.Parallel()
+
+	b, err := logs.OpenMemoryBackend(background, t.Name())
+	assert.NilError(t, err)
+	rm, err := newServerSideMap(b, nil)
+	assert.NilError(t, err)
+
+	// Alice initializes and acquires the resource.
+	try, err := rm.tryInit(background, "treasure", operatorAlice)
+	assert.NilError(t, err)
+	assert.Assert(t, try)
+	assert.NilError(t, rm.completeInit(background, "treasure", operatorAlice))
+	assert.NilError(t, rm.acquire(background, "treasure", operatorAlice, 5, true))
+
+	// Load state from the same backend, taken over by the one waiting for it.
+	opened := asyncResult(func() logs.Backend {
+		b, err := logs.OpenMemoryBackend(background, t.Name())
+		assert.Check(t, err)
+		return b
+	})
+	select {
+	case <-opened:
+		t.Fatal("backend opened twice")
+	case <-time.After(time.Millisecond * 100):
+	}
+	assert.NilError(t, b.Close())
+	b = <-opened
+	t.Cleanup(func() {
+		_ = b.Close()
+	})
+	replayed, err := newServerSideMap(b, nil)
+	assert.NilError(t, err)
+
+	// Init is already completed.
+	try, err = replayed.tryInit(background, "treasure", operatorBob)
+	assert.NilError(t, err)
+	assert.Assert(t, !try)
+
+	// Bob cannot acquire until Alice releases.
+	ctx, cancel := context.WithTimeout(background, time.Millisecond*100)
+	defer cancel()
+	assert.ErrorIs(t,
+		replayed.acquire(ctx, "treasure", operatorBob, 5, false),
+		context.DeadlineExceeded,
+	)
+	assert.NilError(t, replayed.release(background, "treasure", operatorAlice))
+	assert.NilError(t, replayed.acquire(background, "treasure", operatorBob, 5, false))
+}
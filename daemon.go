@@ -0,0 +1,75 @@
+package rsmap
+
+import (
+	"context"
+	"time"
+
+	"github.com/daichitakahashi/rsmap/logs"
+)
+
+// Serve launches the server for `${rsmapDir}/${executionID}/` as a long-lived daemon, and blocks until ctx is canceled.
+// If [WithIdleTimeout] is specified, Serve also returns when the server has been idle for the duration.
+//
+// While the daemon is running, [Map] created by [New] for the same directory acts as a pure client.
+// So, the daemon can be shared across multiple executions of `go test` that have the same execution ID.
+//
+// If another server holds the backend(e.g. `logs.db`), Serve waits until it is released or ctx is canceled.
+// Like [New], Serve refuses `logs.db` of the incompatible schema version.
+func Serve(ctx context.Context, rsmapDir string, opts ...*NewOption) error {
+	var callers logs.CallerContext
+	callers = callers.AppendFrame(callerFrame())
+
+	cfg, _, err := newConfig(rsmapDir, opts)
+	if err != nil {
+		return err
+	}
+	if err := cfg.checkSchema(); err != nil {
+		return err
+	}
+
+	b, err := cfg.openBackend(ctx)
+	if err != nil && ctx.Err() != nil {
+		return nil // Canceled while waiting for the backend.
+	} else if err != nil {
+		return err
+	}
+	defer b.Close()
+
+	select {
+	case <-ctx.Done():
+		return nil
+	default:
+	}
+
+	s, err := startServer(cfg, b, callers)
+	if err != nil {
+		return err
+	}
+	if err := cfg.writeDaemon(s._addr); err != nil {
+		_ = s.stop(context.Background())
+		return err
+	}
+
+	var idle <-chan time.Time
+	if cfg.idleTimeout > 0 {
+		ticker := time.NewTicker(min(cfg.idleTimeout, time.Second))
+		defer ticker.Stop()
+		idle = ticker.C
+	}
+LOOP:
+	for {
+		select {
+		case <-ctx.Done():
+			break LOOP
+		case <-idle:
+			if s.idle(cfg.idleTimeout) {
+				break LOOP
+			}
+		}
+	}
+
+	_ = cfg.removeDaemon()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	return s.stop(shutdownCtx)
+}
@@ -0,0 +1,142 @@
+package rsmap
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/poll"
+)
+
+func TestServe(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Map acts as a pure client while the daemon is running", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		ctx, cancel := context.WithCancel(background)
+		served := asyncResult(func() error {
+			return Serve(ctx, dir, WithExecutionID("daemon"))
+		})
+
+		// Wait for the daemon.
+		daemonFile := filepath.Join(dir, "daemon", "daemon")
+		poll.WaitOn(t, func(t poll.LogT) poll.Result {
+			_, err := os.Stat(daemonFile)
+			if err != nil {
+				return poll.Continue("daemon is not launched yet: %s", err)
+			}
+			return poll.Success()
+		})
+
+		m, err := New(dir, WithExecutionID("daemon"))
+		assert.NilError(t, err)
+		t.Cleanup(m.Close)
+
+		r, err := m.Resource(background, "treasure")
+		assert.NilError(t, err)
+		assert.NilError(t, r.Lock(background))
+		assert.NilError(t, r.UnlockAny())
+
+		// Map never becomes server.
+		_, ok := m.resourceMap().(*clientSideMap)
+		assert.Assert(t, ok)
+
+		cancel()
+		assert.NilError(t, <-served)
+
+		// Mark of the daemon is removed.
+		_, err = os.Stat(daemonFile)
+		assert.Assert(t, os.IsNotExist(err))
+	})
+
+	t.Run("Daemon stops when idle", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		served := asyncResult(func() error {
+			return Serve(background, dir,
+				WithExecutionID("daemon"),
+				WithIdleTimeout(time.Millisecond*500),
+			)
+		})
+
+		select {
+		case err := <-served:
+			assert.NilError(t, err)
+		case <-time.After(time.Second * 10):
+			t.Fatal("daemon did not stop")
+		}
+	})
+
+	t.Run("Map launches server after the daemon stops", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		served := asyncResult(func() error {
+			return Serve(background, dir,
+				WithExecutionID("daemon"),
+				WithIdleTimeout(time.Millisecond*500),
+			)
+		})
+
+		daemonFile := filepath.Join(dir, "daemon", "daemon")
+		poll.WaitOn(t, func(t poll.LogT) poll.Result {
+			_, err := os.Stat(daemonFile)
+			if err != nil {
+				return poll.Continue("daemon is not launched yet: %s", err)
+			}
+			return poll.Success()
+		})
+
+		m, err := New(dir, WithExecutionID("daemon"))
+		assert.NilError(t, err)
+		t.Cleanup(m.Close)
+
+		// Daemon stops while the Map is idle.
+		select {
+		case err := <-served:
+			assert.NilError(t, err)
+		case <-time.After(time.Second * 10):
+			t.Fatal("daemon did not stop")
+		}
+
+		// Next request is served by the server launched by the Map.
+		r, err := m.Resource(background, "treasure")
+		assert.NilError(t, err)
+		assert.NilError(t, r.Lock(background))
+		assert.NilError(t, r.UnlockAny())
+		_, ok := m.resourceMap().(*serverSideMap)
+		assert.Assert(t, ok)
+	})
+	t.Run("Daemon waiting for the backend stops by cancellation", func(t *testing.T) {
+		t.Parallel()
+
+		// Other server holds the backend.
+		dir := t.TempDir()
+		assert.NilError(t, os.MkdirAll(filepath.Join(dir, "daemon"), 0755))
+		b, err := BoltBackend(background, filepath.Join(dir, "daemon"))
+		assert.NilError(t, err)
+		t.Cleanup(func() {
+			_ = b.Close()
+		})
+
+		ctx, cancel := context.WithCancel(background)
+		served := asyncResult(func() error {
+			return Serve(ctx, dir, WithExecutionID("daemon"))
+		})
+		time.Sleep(time.Millisecond * 300)
+		cancel()
+
+		select {
+		case err := <-served:
+			assert.NilError(t, err)
+		case <-time.After(time.Second * 10):
+			t.Fatal("daemon did not stop")
+		}
+	})
+}
@@ -0,0 +1,148 @@
+package rsmap
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	resource_mapv1 "github.com/daichitakahashi/rsmap/internal/proto/resource_map/v1"
+)
+
+const (
+	dashboardPath       = "/"
+	dashboardEventsPath = "/dashboard/events"
+)
+
+//go:embed dashboard.html
+var dashboardHTML []byte
+
+type (
+	// JSON representation of the status for the dashboard.
+	dashboardStatus struct {
+		Addr       string              `json:"addr"`
+		LaunchedAt time.Time           `json:"launchedAt"`
+		Resources  []dashboardResource `json:"resources"`
+	}
+
+	dashboardResource struct {
+		Name         string                 `json:"name"`
+		Max          int64                  `json:"max"`
+		Holders      []dashboardAcquisition `json:"holders"`
+		Waiters      []dashboardAcquisition `json:"waiters"`
+		Init         string                 `json:"init"`
+		InitOperator string                 `json:"initOperator"`
+	}
+
+	dashboardAcquisition struct {
+		Operator string `json:"operator"`
+		N        int64  `json:"n"`
+	}
+
+	// JSON representation of the event for the dashboard.
+	dashboardEvent struct {
+		Kind         string    `json:"kind"`
+		ResourceName string    `json:"resourceName"`
+		Operator     string    `json:"operator"`
+		Timestamp    time.Time `json:"timestamp"`
+	}
+)
+
+func newDashboardStatus(s *Status) dashboardStatus {
+	convert := func(acquisitions []Acquisition) []dashboardAcquisition {
+		converted := make([]dashboardAcquisition, 0, len(acquisitions))
+		for _, a := range acquisitions {
+			converted = append(converted, dashboardAcquisition{
+				Operator: a.Operator.String(),
+				N:        a.N,
+			})
+		}
+		return converted
+	}
+
+	status := dashboardStatus{
+		Addr:       s.Addr,
+		LaunchedAt: s.LaunchedAt,
+		Resources:  make([]dashboardResource, 0, len(s.Resources)),
+	}
+	for _, r := range s.Resources {
+		status.Resources = append(status.Resources, dashboardResource{
+			Name:         r.Name,
+			Max:          r.Max,
+			Holders:      convert(r.Holders),
+			Waiters:      convert(r.Waiters),
+			Init:         r.Init.String(),
+			InitOperator: r.InitOperator.String(),
+		})
+	}
+	return status
+}
+
+func newDashboardEvent(e Event) dashboardEvent {
+	return dashboardEvent{
+		Kind:         e.Kind.String(),
+		ResourceName: e.ResourceName,
+		Operator:     e.Operator.String(),
+		Timestamp:    e.Timestamp,
+	}
+}
+
+// Serve the dashboard page.
+func (h *resourceMapHandler) dashboard(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != dashboardPath {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(dashboardHTML)
+}
+
+// Stream events and the status after them as Server-Sent Events.
+func (h *resourceMapHandler) dashboardEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+
+	send := func(event string, v any) error {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+		return err
+	}
+	sendStatus := func() error {
+		resources, err := h._rm.status()
+		if err != nil {
+			return err
+		}
+		return send("status", newDashboardStatus(statusFromResponse(&resource_mapv1.GetStatusResponse{
+			Addr:       h._addr,
+			LaunchedAt: h._launchedAt.UnixNano(),
+			Resources:  resources,
+		})))
+	}
+
+	sub := h._broker.subscribe(nil)
+	defer h._broker.unsubscribe(sub)
+
+	if err := sendStatus(); err != nil {
+		return
+	}
+	flusher.Flush()
+	_ = sub.stream(r.Context(), h._closing, func(resp *resource_mapv1.WatchResponse) error {
+		if err := send("event", newDashboardEvent(eventFromResponse(resp))); err != nil {
+			return err
+		}
+		if err := sendStatus(); err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	})
+}
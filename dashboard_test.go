@@ -0,0 +1,122 @@
+package rsmap
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/poll"
+)
+
+func TestWithDashboard(t *testing.T) {
+	t.Parallel()
+
+	launch := func(t *testing.T, opts ...*NewOption) (*Map, string) {
+		t.Helper()
+
+		dir := t.TempDir()
+		m, err := New(dir, append(opts, WithExecutionID("dashboard"))...)
+		assert.NilError(t, err)
+		t.Cleanup(m.Close)
+
+		var addr string
+		poll.WaitOn(t, func(t poll.LogT) poll.Result {
+			data, err := os.ReadFile(filepath.Join(dir, "dashboard", "addr"))
+			if err != nil {
+				return poll.Continue("server is not launched yet: %s", err)
+			}
+			addr = string(data)
+			return poll.Success()
+		})
+		return m, addr
+	}
+
+	t.Run("Dashboard is disabled by default", func(t *testing.T) {
+		t.Parallel()
+
+		_, addr := launch(t)
+		resp, err := http.Get(addr + dashboardPath)
+		assert.NilError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, resp.StatusCode, http.StatusNotFound)
+	})
+
+	t.Run("Dashboard shows live status and events", func(t *testing.T) {
+		t.Parallel()
+
+		m, addr := launch(t, WithDashboard())
+
+		resp, err := http.Get(addr + dashboardPath)
+		assert.NilError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		assert.NilError(t, err)
+		assert.Equal(t, resp.StatusCode, http.StatusOK)
+		assert.Assert(t, strings.Contains(string(body), "<title>rsmap dashboard</title>"))
+
+		ctx, cancel := context.WithCancel(background)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, addr+dashboardEventsPath, nil)
+		assert.NilError(t, err)
+		resp, err = http.DefaultClient.Do(req)
+		assert.NilError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, resp.Header.Get("Content-Type"), "text/event-stream")
+
+		sc := bufio.NewScanner(resp.Body)
+		next := func(t *testing.T) (string, string) {
+			t.Helper()
+
+			var event, data string
+			for sc.Scan() {
+				line := sc.Text()
+				switch {
+				case strings.HasPrefix(line, "event: "):
+					event = strings.TrimPrefix(line, "event: ")
+				case strings.HasPrefix(line, "data: "):
+					data = strings.TrimPrefix(line, "data: ")
+				case line == "":
+					return event, data
+				}
+			}
+			t.Fatalf("stream is closed: %v", sc.Err())
+			return "", ""
+		}
+
+		// Current status is sent first.
+		event, _ := next(t)
+		assert.Equal(t, event, "status")
+
+		r, err := m.Resource(background, "treasure")
+		assert.NilError(t, err)
+		assert.NilError(t, r.Lock(background))
+
+		// Event and the status after it are sent.
+		var (
+			e      dashboardEvent
+			status dashboardStatus
+		)
+		for e.Kind != EventAcquired.String() {
+			event, data := next(t)
+			assert.Equal(t, event, "event")
+			assert.NilError(t, json.Unmarshal([]byte(data), &e))
+
+			event, data = next(t)
+			assert.Equal(t, event, "status")
+			assert.NilError(t, json.Unmarshal([]byte(data), &status))
+		}
+		assert.Equal(t, e.ResourceName, "treasure")
+		assert.Equal(t, status.Addr, addr)
+		assert.Equal(t, len(status.Resources), 1)
+		assert.DeepEqual(t, status.Resources[0].Holders, []dashboardAcquisition{
+			{Operator: r._operator.Context.String(), N: 5},
+		})
+	})
+}
@@ -0,0 +1,494 @@
+package rsmap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daichitakahashi/rsmap/internal/flock"
+	logsv1 "github.com/daichitakahashi/rsmap/internal/proto/logs/v1"
+	resource_mapv1 "github.com/daichitakahashi/rsmap/internal/proto/resource_map/v1"
+	"github.com/daichitakahashi/rsmap/logs"
+)
+
+// resourceMap using OS file locks, without server.
+//
+// Each resource has lock files under `${rsmapDir}/${executionID}/locks/${resourceName}/`.
+//   - intent.lock: Locked in exclusive mode by exclusive lock while waiting for the gate, so that new shared locks
+//     don't starve it. Shared lock takes it in shared mode only while trying the gate.
+//   - gate.lock: Locked in shared mode by shared lock, and in exclusive mode by exclusive lock.
+//   - slot-${i}.lock: One of them is locked by shared lock, to limit max parallelism.
+//   - init.lock: Locked while the resource is initialized.
+//   - init.done: Created when initialization is completed.
+//
+// Because the locks are released by OS when the process exits, no failover is required.
+type fileLockMap struct {
+	_cfg  config
+	_mu   sync.Mutex
+	_held map[string][]*os.File
+	// Closed when the acquisition in flight finishes.
+	_acquiring map[string]chan struct{}
+	_inits     map[string]*os.File
+	_recorder  *fileLockRecorder
+}
+
+func newFileLockMap(cfg config) *fileLockMap {
+	return &fileLockMap{
+		_cfg:       cfg,
+		_held:      map[string][]*os.File{},
+		_acquiring: map[string]chan struct{}{},
+		_inits:     map[string]*os.File{},
+		_recorder:  newFileLockRecorder(cfg),
+	}
+}
+
+const fileLockInterval = time.Millisecond * 10
+
+// Directory of the lock files of the resource.
+// The name is escaped and prefixed, so that the names like "." and ".." never resolve outside of "locks".
+func (m *fileLockMap) resourceDir(resourceName string) (string, error) {
+	dir := filepath.Join(m._cfg.dir, "locks", "r_"+url.PathEscape(resourceName))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+func lockFile(ctx context.Context, filename string, mode flock.Mode) (*os.File, error) {
+	f, err := os.OpenFile(filename, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if err := flock.Lock(ctx, f, mode); err != nil {
+		return nil, errors.Join(err, f.Close())
+	}
+	return f, nil
+}
+
+func unlockFile(f *os.File) error {
+	return errors.Join(
+		flock.Unlock(f),
+		f.Close(),
+	)
+}
+
+func lockKey(resourceName string, operator logs.Operator) string {
+	return resourceName + "\x00" + operator.ID
+}
+
+const (
+	// Duration to keep the backend open after the last event.
+	fileLockRecordLinger = time.Millisecond * 50
+	// Duration to wait for the backend held by other process. The event is not recorded after that.
+	fileLockRecordTimeout = time.Second * 5
+)
+
+type (
+	// Record events to the backend, so that viewlogs can read them.
+	// The backend is opened once for the events in succession, and closed when no event arrives for a while,
+	// because other processes sharing the backend cannot write events while it is opened.
+	fileLockRecorder struct {
+		_cfg    config
+		_mu     sync.RWMutex
+		_closed bool
+		_reqs   chan recordRequest
+		_done   chan struct{}
+	}
+
+	recordRequest struct {
+		fn     func(b logs.Backend) error
+		result chan<- error
+	}
+)
+
+func newFileLockRecorder(cfg config) *fileLockRecorder {
+	r := &fileLockRecorder{
+		_cfg:  cfg,
+		_reqs: make(chan recordRequest),
+		_done: make(chan struct{}),
+	}
+	go r.run()
+	return r
+}
+
+func (r *fileLockRecorder) run() {
+	defer close(r._done)
+
+	var b logs.Backend
+	closeBackend := func() {
+		if b != nil {
+			_ = b.Close()
+			b = nil
+		}
+	}
+	defer closeBackend()
+
+	for {
+		var linger <-chan time.Time
+		if b != nil {
+			linger = time.After(fileLockRecordLinger)
+		}
+		select {
+		case req, ok := <-r._reqs:
+			if !ok {
+				return
+			}
+			if b == nil {
+				ctx, cancel := context.WithTimeout(context.Background(), fileLockRecordTimeout)
+				var err error
+				b, err = r._cfg.openBackend(ctx)
+				cancel()
+				if err != nil {
+					req.result <- err
+					continue
+				}
+			}
+			req.result <- req.fn(b)
+		case <-linger:
+			closeBackend()
+		}
+	}
+}
+
+func (r *fileLockRecorder) record(fn func(b logs.Backend) error) error {
+	r._mu.RLock()
+	if r._closed {
+		r._mu.RUnlock()
+		return errClosing
+	}
+	result := make(chan error, 1)
+	r._reqs <- recordRequest{
+		fn:     fn,
+		result: result,
+	}
+	r._mu.RUnlock()
+	return <-result
+}
+
+// Close the backend after all events are recorded.
+func (r *fileLockRecorder) close() {
+	r._mu.Lock()
+	if !r._closed {
+		r._closed = true
+		close(r._reqs)
+	}
+	r._mu.Unlock()
+	<-r._done
+}
+
+func (m *fileLockMap) record(fn func(b logs.Backend) error) error {
+	return m._recorder.record(fn)
+}
+
+func (m *fileLockMap) recordInit(resourceName string, operator logs.Operator, event logsv1.InitEvent) error {
+	return m.record(func(b logs.Backend) error {
+		return b.InitRecordStore().Put([]string{resourceName}, func(_ string, r *logsv1.InitRecord, _ bool) {
+			r.Logs = append(r.Logs, &logsv1.InitLog{
+				Event:      event,
+				Context:    operator.Context,
+				OperatorId: operator.ID,
+				Timestamp:  time.Now().UnixNano(),
+			})
+		})
+	})
+}
+
+// Record the acquisition log. exclusive is nil for RELEASED.
+func (m *fileLockMap) recordAcquisition(resourceName string, operator logs.Operator, max, n int64, event logsv1.AcquisitionEvent, exclusive *bool) error {
+	return m.record(func(b logs.Backend) error {
+		return b.AcquisitionRecordStore().Put([]string{resourceName}, func(_ string, r *logsv1.AcquisitionRecord, update bool) {
+			// Initial acquisition.
+			if !update {
+				r.Max = max
+			}
+			r.Logs = append(r.Logs, &logsv1.AcquisitionLog{
+				Event:      event,
+				N:          n,
+				Context:    operator.Context,
+				OperatorId: operator.ID,
+				Timestamp:  time.Now().UnixNano(),
+				Exclusive:  exclusive,
+			})
+		})
+	})
+}
+
+func (m *fileLockMap) tryInit(ctx context.Context, resourceName string, operator logs.Operator) (bool, error) {
+	dir, err := m.resourceDir(resourceName)
+	if err != nil {
+		return false, err
+	}
+
+	f, err := lockFile(ctx, filepath.Join(dir, "init.lock"), flock.Exclusive)
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(filepath.Join(dir, "init.done")); err == nil {
+		// Already completed.
+		return false, unlockFile(f)
+	}
+
+	m._mu.Lock()
+	m._inits[lockKey(resourceName, operator)] = f
+	m._mu.Unlock()
+
+	return true, m.recordInit(resourceName, operator, logsv1.InitEvent_INIT_EVENT_STARTED)
+}
+
+func (m *fileLockMap) finishInit(resourceName string, operator logs.Operator, completed bool) error {
+	key := lockKey(resourceName, operator)
+	m._mu.Lock()
+	f, ok := m._inits[key]
+	delete(m._inits, key)
+	m._mu.Unlock()
+	if !ok {
+		return errors.New("invalid operation")
+	}
+	defer func() {
+		_ = unlockFile(f)
+	}()
+
+	event := logsv1.InitEvent_INIT_EVENT_FAILED
+	if completed {
+		event = logsv1.InitEvent_INIT_EVENT_COMPLETED
+		dir, err := m.resourceDir(resourceName)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dir, "init.done"), nil, 0644); err != nil {
+			return err
+		}
+	}
+	return m.recordInit(resourceName, operator, event)
+}
+
+func (m *fileLockMap) completeInit(_ context.Context, resourceName string, operator logs.Operator) error {
+	return m.finishInit(resourceName, operator, true)
+}
+
+func (m *fileLockMap) failInit(_ context.Context, resourceName string, operator logs.Operator) error {
+	return m.finishInit(resourceName, operator, false)
+}
+
+// Take the gate in shared mode. While the exclusive lock is waiting for the gate with the intent,
+// new shared lock is not taken.
+func lockGateShared(ctx context.Context, dir string) (*os.File, error) {
+	for {
+		intent, err := lockFile(ctx, filepath.Join(dir, "intent.lock"), flock.Shared)
+		if err != nil {
+			return nil, err
+		}
+		gate, err := os.OpenFile(filepath.Join(dir, "gate.lock"), os.O_RDWR|os.O_CREATE, 0644)
+		if err != nil {
+			return nil, errors.Join(err, unlockFile(intent))
+		}
+		ok, err := flock.TryLock(gate, flock.Shared)
+		err = errors.Join(err, unlockFile(intent))
+		if err != nil {
+			return nil, errors.Join(err, gate.Close())
+		}
+		if ok {
+			return gate, nil
+		}
+		_ = gate.Close()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(fileLockInterval):
+		}
+	}
+}
+
+// Acquire lock files. Exclusive lock takes all slots(max) by locking the gate exclusively.
+// Shared lock takes the gate in shared mode and one of the slots.
+func (m *fileLockMap) lock(ctx context.Context, dir string, max int64, exclusive bool) ([]*os.File, int64, error) {
+	if exclusive {
+		// Hold the intent while waiting for the holders of shared lock being drained.
+		intent, err := lockFile(ctx, filepath.Join(dir, "intent.lock"), flock.Exclusive)
+		if err != nil {
+			return nil, 0, err
+		}
+		gate, err := lockFile(ctx, filepath.Join(dir, "gate.lock"), flock.Exclusive)
+		err = errors.Join(err, unlockFile(intent))
+		if err != nil {
+			if gate != nil {
+				err = errors.Join(err, unlockFile(gate))
+			}
+			return nil, 0, err
+		}
+		return []*os.File{gate}, max, nil
+	}
+
+	gate, err := lockGateShared(ctx, dir)
+	if err != nil {
+		return nil, 0, err
+	}
+	for {
+		for i := int64(0); i < max; i++ {
+			slot, err := os.OpenFile(filepath.Join(dir, fmt.Sprintf("slot-%d.lock", i)), os.O_RDWR|os.O_CREATE, 0644)
+			if err != nil {
+				return nil, 0, errors.Join(err, unlockFile(gate))
+			}
+			ok, err := flock.TryLock(slot, flock.Exclusive)
+			if err != nil {
+				return nil, 0, errors.Join(err, slot.Close(), unlockFile(gate))
+			}
+			if ok {
+				return []*os.File{gate, slot}, 1, nil
+			}
+			_ = slot.Close()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, 0, errors.Join(ctx.Err(), unlockFile(gate))
+		case <-time.After(fileLockInterval):
+		}
+	}
+}
+
+func (m *fileLockMap) acquire(ctx context.Context, resourceName string, operator logs.Operator, max int64, exclusive bool) error {
+	key := lockKey(resourceName, operator)
+	var done chan struct{}
+	for done == nil {
+		m._mu.Lock()
+		if _, ok := m._held[key]; ok {
+			m._mu.Unlock()
+			// If already acquired by this operator, return without acquisition.
+			return nil
+		}
+		acquiring, ok := m._acquiring[key]
+		if !ok {
+			done = make(chan struct{})
+			m._acquiring[key] = done
+		}
+		m._mu.Unlock()
+
+		if ok {
+			// Wait for the acquisition in flight by this operator, and check the result again.
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-acquiring:
+			}
+		}
+	}
+
+	files, n, err := func() ([]*os.File, int64, error) {
+		dir, err := m.resourceDir(resourceName)
+		if err != nil {
+			return nil, 0, err
+		}
+		err = m.recordAcquisition(resourceName, operator, max, 0, logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING, proto.Bool(exclusive))
+		if err != nil {
+			return nil, 0, err
+		}
+		files, n, err := m.lock(ctx, dir, max, exclusive)
+		if err != nil {
+			// Gave up waiting.
+			return nil, 0, errors.Join(err,
+				m.recordAcquisition(resourceName, operator, max, 0, logsv1.AcquisitionEvent_ACQUISITION_EVENT_CANCELED, proto.Bool(exclusive)),
+			)
+		}
+		return files, n, nil
+	}()
+	m._mu.Lock()
+	if err == nil {
+		m._held[key] = files
+	}
+	delete(m._acquiring, key)
+	close(done)
+	m._mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return m.recordAcquisition(resourceName, operator, max, n, logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED, proto.Bool(exclusive))
+}
+
+func (m *fileLockMap) acquireMulti(ctx context.Context, resources []*resource_mapv1.AcquireMultiEntry) error {
+	// Acquire in the order of resource name to avoid deadlock.
+	entries := slices.Clone(resources)
+	slices.SortFunc(entries, func(a, b *resource_mapv1.AcquireMultiEntry) int {
+		switch {
+		case a.ResourceName < b.ResourceName:
+			return -1
+		case a.ResourceName > b.ResourceName:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	for i, e := range entries {
+		err := m.acquire(ctx, e.ResourceName, logs.OperatorOf(e), e.MaxParallelism, e.Exclusive)
+		if err != nil {
+			// Release locks acquired so far.
+			for _, acquired := range entries[:i] {
+				err = errors.Join(err, m.release(ctx, acquired.ResourceName, logs.OperatorOf(acquired)))
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *fileLockMap) release(_ context.Context, resourceName string, operator logs.Operator) error {
+	key := lockKey(resourceName, operator)
+	m._mu.Lock()
+	files, ok := m._held[key]
+	if !ok {
+		m._mu.Unlock()
+		// If not acquired, return without error.
+		return nil
+	}
+	delete(m._held, key)
+	m._mu.Unlock()
+
+	// Release in reverse order, before recording the event which may wait for the backend.
+	var err error
+	for i := len(files) - 1; i >= 0; i-- {
+		err = errors.Join(err, unlockFile(files[i]))
+	}
+	return errors.Join(err,
+		m.recordAcquisition(resourceName, operator, 0, 0, logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED, nil),
+	)
+}
+
+func (m *fileLockMap) releaseMulti(ctx context.Context, resources []*resource_mapv1.ReleaseMultiEntry) error {
+	var err error
+	for _, e := range resources {
+		err = errors.Join(err, m.release(ctx, e.ResourceName, logs.OperatorOf(e)))
+	}
+	return err
+}
+
+// Release all locks held by this map, and close the backend.
+func (m *fileLockMap) close() {
+	m._recorder.close()
+
+	m._mu.Lock()
+	defer m._mu.Unlock()
+
+	for key, files := range m._held {
+		for i := len(files) - 1; i >= 0; i-- {
+			_ = unlockFile(files[i])
+		}
+		delete(m._held, key)
+	}
+	for key, f := range m._inits {
+		_ = unlockFile(f)
+		delete(m._inits, key)
+	}
+}
+
+var _ resourceMap = (*fileLockMap)(nil)
@@ -0,0 +1,275 @@
+package rsmap
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/poll"
+
+	"github.com/daichitakahashi/rsmap/internal/flock"
+	logsv1 "github.com/daichitakahashi/rsmap/internal/proto/logs/v1"
+	"github.com/daichitakahashi/rsmap/logs"
+)
+
+func TestWithFileLock(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	newMap := func(t *testing.T) *Map {
+		t.Helper()
+
+		m, err := New(dir, WithExecutionID("filelock"), WithFileLock())
+		assert.NilError(t, err)
+		t.Cleanup(m.Close)
+		return m
+	}
+	ctxWithTimeout := func(t *testing.T) context.Context {
+		t.Helper()
+		ctx, cancel := context.WithTimeout(background, time.Millisecond*200)
+		t.Cleanup(cancel)
+		return ctx
+	}
+
+	// Init is performed only once.
+	var count int64
+	init := WithInit(func(ctx context.Context) error {
+		atomic.AddInt64(&count, 1)
+		return nil
+	})
+	newResource := func(t *testing.T) *Resource {
+		t.Helper()
+
+		r, err := newMap(t).Resource(background, "treasure", init, WithMaxParallelism(2))
+		assert.NilError(t, err)
+		return r
+	}
+	r1 := newResource(t)
+	r2 := newResource(t)
+	r3 := newResource(t)
+	assert.Equal(t, count, int64(1))
+
+	// Shared locks are acquired until max parallelism.
+	assert.NilError(t, r1.RLock(ctxWithTimeout(t)))
+	assert.NilError(t, r2.RLock(ctxWithTimeout(t)))
+	assert.ErrorIs(t, r3.RLock(ctxWithTimeout(t)), context.DeadlineExceeded)
+
+	// Exclusive lock waits for release of shared locks.
+	assert.ErrorIs(t, r3.Lock(ctxWithTimeout(t)), context.DeadlineExceeded)
+	assert.NilError(t, r1.UnlockAny())
+	assert.NilError(t, r2.UnlockAny())
+	assert.NilError(t, r3.Lock(ctxWithTimeout(t)))
+	assert.ErrorIs(t, r1.RLock(ctxWithTimeout(t)), context.DeadlineExceeded)
+	assert.NilError(t, r3.UnlockAny())
+
+	// Multiple locks.
+	precious, err := r1._m.Resource(background, "precious")
+	assert.NilError(t, err)
+	unlock, err := LockResources(ctxWithTimeout(t), precious.Exclusive(), r1.Shared())
+	assert.NilError(t, err)
+	assert.ErrorIs(t, r2.Lock(ctxWithTimeout(t)), context.DeadlineExceeded)
+	assert.NilError(t, unlock())
+	assert.NilError(t, r2.Lock(ctxWithTimeout(t)))
+	assert.NilError(t, r2.UnlockAny())
+
+	// Events are recorded.
+	b, err := logs.OpenBoltBackend(background, filepath.Join(dir, "filelock", "logs.db"))
+	assert.NilError(t, err)
+	defer b.Close()
+
+	initRecord, err := b.InitRecordStore().Get("treasure")
+	assert.NilError(t, err)
+	assert.Equal(t, len(initRecord.Logs), 2)
+	assert.Equal(t, initRecord.Logs[0].Event, logsv1.InitEvent_INIT_EVENT_STARTED)
+	assert.Equal(t, initRecord.Logs[1].Event, logsv1.InitEvent_INIT_EVENT_COMPLETED)
+
+	acquisitionRecord, err := b.AcquisitionRecordStore().Get("treasure")
+	assert.NilError(t, err)
+	assert.Equal(t, acquisitionRecord.Max, int64(2))
+	var acquired, canceled int
+	for _, l := range acquisitionRecord.Logs {
+		switch l.Event {
+		case logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED:
+			acquired++
+		case logsv1.AcquisitionEvent_ACQUISITION_EVENT_CANCELED:
+			canceled++
+		}
+	}
+	assert.Equal(t, acquired, 5)
+	assert.Equal(t, canceled, 4) // Timed out.
+}
+
+func TestWithFileLock_ExclusivePriority(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	m, err := New(dir, WithExecutionID("filelock"), WithFileLock())
+	assert.NilError(t, err)
+	t.Cleanup(m.Close)
+
+	newResource := func(t *testing.T) *Resource {
+		t.Helper()
+
+		r, err := m.Resource(background, "treasure", WithMaxParallelism(2))
+		assert.NilError(t, err)
+		return r
+	}
+	r1 := newResource(t)
+	r2 := newResource(t)
+	r3 := newResource(t)
+
+	assert.NilError(t, r1.RLock(background))
+	locked := asyncResult(func() error {
+		return r3.Lock(background)
+	})
+
+	// Wait for r3 declaring the intent of exclusive lock.
+	poll.WaitOn(t, func(t poll.LogT) poll.Result {
+		f, err := os.Open(filepath.Join(dir, "filelock", "locks", "r_treasure", "intent.lock"))
+		if err != nil {
+			return poll.Continue("intent.lock is not created yet: %s", err)
+		}
+		defer f.Close()
+		ok, err := flock.TryLock(f, flock.Shared)
+		if err != nil {
+			return poll.Error(err)
+		}
+		if ok {
+			_ = flock.Unlock(f)
+			return poll.Continue("intent is not declared yet")
+		}
+		return poll.Success()
+	})
+
+	// New shared lock doesn't overtake the exclusive lock waiting for the release of r1.
+	ctx, cancel := context.WithTimeout(background, time.Millisecond*200)
+	defer cancel()
+	assert.ErrorIs(t, r2.RLock(ctx), context.DeadlineExceeded)
+
+	assert.NilError(t, r1.UnlockAny())
+	assert.NilError(t, <-locked)
+	assert.NilError(t, r3.UnlockAny())
+	assert.NilError(t, r2.RLock(background))
+	assert.NilError(t, r2.UnlockAny())
+}
+
+func TestWithFileLock_AcquisitionInFlight(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	m, err := New(dir, WithExecutionID("filelock"), WithFileLock())
+	assert.NilError(t, err)
+	t.Cleanup(m.Close)
+
+	r1, err := m.Resource(background, "treasure")
+	assert.NilError(t, err)
+	r2, err := m.Resource(background, "treasure")
+	assert.NilError(t, err)
+
+	assert.NilError(t, r1.Lock(background))
+
+	// Both of the acquisitions by the same operator wait for the lock.
+	first := asyncResult(func() error {
+		return r2.Lock(background)
+	})
+	second := asyncResult(func() error {
+		return r2.Lock(background)
+	})
+	select {
+	case <-first:
+		t.Fatal("acquired while the lock is held by other")
+	case <-second:
+		t.Fatal("acquired while the lock is held by other")
+	case <-time.After(time.Millisecond * 200):
+	}
+
+	assert.NilError(t, r1.UnlockAny())
+	assert.NilError(t, <-first)
+	assert.NilError(t, <-second)
+	assert.NilError(t, r2.UnlockAny())
+	assert.NilError(t, r1.Lock(background))
+	assert.NilError(t, r1.UnlockAny())
+}
+
+func TestWithFileLock_ReleaseWhileBackendIsHeld(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	m, err := New(dir, WithExecutionID("filelock"), WithFileLock())
+	assert.NilError(t, err)
+	t.Cleanup(m.Close)
+
+	r, err := m.Resource(background, "treasure")
+	assert.NilError(t, err)
+	assert.NilError(t, r.Lock(background))
+
+	// Other process holds the backend.
+	b, err := logs.OpenBoltBackend(background, filepath.Join(dir, "filelock", "logs.db"))
+	assert.NilError(t, err)
+	closeBackend := sync.OnceValue(b.Close)
+	t.Cleanup(func() {
+		_ = closeBackend()
+	})
+	released := asyncResult(r.UnlockAny)
+
+	// Lock files are released without waiting for the backend.
+	lockDir, err := m.resourceMap().(*fileLockMap).resourceDir("treasure")
+	assert.NilError(t, err)
+	poll.WaitOn(t, func(t poll.LogT) poll.Result {
+		f, err := os.Open(filepath.Join(lockDir, "gate.lock"))
+		if err != nil {
+			return poll.Error(err)
+		}
+		defer f.Close()
+		ok, err := flock.TryLock(f, flock.Exclusive)
+		if err != nil {
+			return poll.Error(err)
+		}
+		if !ok {
+			return poll.Continue("gate.lock is still held")
+		}
+		_ = flock.Unlock(f)
+		return poll.Success()
+	})
+
+	// The event is recorded after the backend is released.
+	assert.NilError(t, closeBackend())
+	assert.NilError(t, <-released)
+}
+
+func TestWithFileLock_ResourceDir(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	m, err := New(dir, WithExecutionID("filelock"), WithFileLock())
+	assert.NilError(t, err)
+	t.Cleanup(m.Close)
+	fm := m.resourceMap().(*fileLockMap)
+
+	// Names like "." and ".." never resolve outside of the locks directory, nor collide with each other.
+	locksDir := filepath.Join(dir, "filelock", "locks")
+	seen := map[string]string{}
+	for _, name := range []string{".", "..", "", "treasure", "../treasure", "a/b"} {
+		resourceDir, err := fm.resourceDir(name)
+		assert.NilError(t, err)
+		assert.Equal(t, filepath.Dir(resourceDir), locksDir, "resource %q", name)
+		other, ok := seen[resourceDir]
+		assert.Assert(t, !ok, "resource %q collides with %q", name, other)
+		seen[resourceDir] = name
+	}
+
+	// Each resource is locked independently.
+	r1, err := m.Resource(background, ".")
+	assert.NilError(t, err)
+	r2, err := m.Resource(background, "..")
+	assert.NilError(t, err)
+	assert.NilError(t, r1.Lock(background))
+	assert.NilError(t, r2.Lock(background))
+	assert.NilError(t, r1.UnlockAny())
+	assert.NilError(t, r2.UnlockAny())
+}
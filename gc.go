@@ -0,0 +1,226 @@
+package rsmap
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"go.etcd.io/bbolt"
+
+	"github.com/daichitakahashi/rsmap/internal/flock"
+)
+
+type retention struct {
+	maxAge   time.Duration
+	maxCount int
+}
+
+// Files and directories created under `${rsmapDir}/${executionID}/`.
+// Only the directory containing any of them is regarded as the execution directory.
+var executionEntries = []string{"logs.db", "logs.journal", "addr", "daemon", "locks"}
+
+type execution struct {
+	dir     string
+	modTime time.Time
+}
+
+// GC removes old execution directories `${rsmapDir}/${executionID}/`.
+// Directories older than maxAge, and directories exceeding maxCount from the newest one are removed.
+// If maxAge or maxCount is zero, it is not limited.
+//
+// The directory whose backend or lock files are still locked by a live process, or cannot be opened to check it, is never removed.
+// GC returns the removed directories.
+func GC(rsmapDir string, maxAge time.Duration, maxCount int) ([]string, error) {
+	return collectGarbage(rsmapDir, retention{
+		maxAge:   maxAge,
+		maxCount: maxCount,
+	}, "")
+}
+
+func collectGarbage(rsmapDir string, r retention, exclude string) ([]string, error) {
+	if r.maxAge <= 0 && r.maxCount <= 0 {
+		return nil, nil
+	}
+	executions, err := listExecutions(rsmapDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		now     = time.Now()
+		removed []string
+		errs    []error
+	)
+	for i, e := range executions {
+		if e.dir == exclude ||
+			(r.maxCount <= 0 || i < r.maxCount) && (r.maxAge <= 0 || now.Sub(e.modTime) <= r.maxAge) {
+			continue
+		}
+		ok, err := removeExecution(e.dir)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if ok {
+			removed = append(removed, e.dir)
+		}
+	}
+	return removed, errors.Join(errs...)
+}
+
+// Remove the execution directory if it is not in use.
+//
+// A process may open the backend between the check and the removal. To avoid removing the files in use,
+// the directory is moved aside first, and checked again. Since the locks follow the files, the process
+// which opened them before the move is detected, and the process opening them after the move creates a new directory.
+// If the directory turns out to be in use, it is moved back.
+func removeExecution(dir string) (bool, error) {
+	inUse, err := executionInUse(dir)
+	if inUse || err != nil {
+		return false, err
+	}
+
+	trash := filepath.Join(filepath.Dir(dir), "."+filepath.Base(dir)+".gc")
+	if err := os.Rename(dir, trash); errors.Is(err, fs.ErrNotExist) {
+		return false, nil // Removed by another GC.
+	} else if err != nil {
+		return false, err
+	}
+	inUse, err = executionInUse(trash)
+	if inUse || err != nil {
+		return false, errors.Join(err, os.Rename(trash, dir))
+	}
+	return true, os.RemoveAll(trash)
+}
+
+// List execution directories under rsmapDir, from the newest one.
+func listExecutions(rsmapDir string) ([]execution, error) {
+	entries, err := os.ReadDir(rsmapDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var executions []execution
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		dir := filepath.Join(rsmapDir, entry.Name())
+		modTime, ok, err := executionModTime(dir)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			executions = append(executions, execution{
+				dir:     dir,
+				modTime: modTime,
+			})
+		}
+	}
+	slices.SortFunc(executions, func(a, b execution) int {
+		return b.modTime.Compare(a.modTime)
+	})
+	return executions, nil
+}
+
+// Get the last modification time of the files in the execution directory.
+// If the directory is not the execution directory, ok is false.
+func executionModTime(dir string) (modTime time.Time, ok bool, err error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	for _, entry := range entries {
+		if !slices.Contains(executionEntries, entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return time.Time{}, false, err
+		}
+		ok = true
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+	return modTime, ok, nil
+}
+
+// Check whether the backend or lock files in the execution directory are locked by a live process.
+func executionInUse(dir string) (bool, error) {
+	locked, err := boltLocked(filepath.Join(dir, "logs.db"))
+	if locked || err != nil {
+		return locked, err
+	}
+	locked, err = fileLocked(filepath.Join(dir, "logs.journal"))
+	if locked || err != nil {
+		return locked, err
+	}
+
+	// Lock files of serverless mode.
+	err = filepath.WalkDir(filepath.Join(dir, "locks"), func(path string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		locked, err = fileLocked(path)
+		if err != nil {
+			return err
+		}
+		if locked {
+			return fs.SkipAll
+		}
+		return nil
+	})
+	return locked, err
+}
+
+// Check whether the bbolt database is opened by other process.
+// Because bbolt locks the region of the file different from [flock], open it read-only with the shortest timeout.
+func boltLocked(filename string) (bool, error) {
+	f, err := os.Open(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	_ = f.Close()
+
+	db, err := bbolt.Open(filename, 0644, &bbolt.Options{
+		ReadOnly: true,
+		Timeout:  time.Nanosecond,
+	})
+	if err != nil {
+		// Unless the lock is acquired, the file may be in use.
+		// Even if the file is broken, leave it to be inspected.
+		return true, nil
+	}
+	return false, db.Close()
+}
+
+func fileLocked(filename string) (bool, error) {
+	f, err := os.OpenFile(filename, os.O_RDWR, 0)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	ok, err := flock.TryLock(f, flock.Exclusive)
+	if err != nil || !ok {
+		return !ok, err
+	}
+	return false, flock.Unlock(f)
+}
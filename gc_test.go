@@ -0,0 +1,144 @@
+package rsmap
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/poll"
+)
+
+func TestGC(t *testing.T) {
+	t.Parallel()
+
+	// Create execution directory having the file modified at the time.
+	prepare := func(t *testing.T, dir, name string, modTime time.Time) string {
+		t.Helper()
+
+		execDir := filepath.Join(dir, name)
+		assert.NilError(t, os.MkdirAll(execDir, 0755))
+		filename := filepath.Join(execDir, "addr")
+		assert.NilError(t, os.WriteFile(filename, []byte("http://127.0.0.1:0"), 0644))
+		assert.NilError(t, os.Chtimes(filename, modTime, modTime))
+		return execDir
+	}
+	exists := func(t *testing.T, dir string) bool {
+		t.Helper()
+
+		_, err := os.Stat(dir)
+		if os.IsNotExist(err) {
+			return false
+		}
+		assert.NilError(t, err)
+		return true
+	}
+
+	t.Run("Old directories are removed by age and count", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			dir     = t.TempDir()
+			now     = time.Now()
+			newest  = prepare(t, dir, "newest", now)
+			newer   = prepare(t, dir, "newer", now.Add(-time.Hour))
+			older   = prepare(t, dir, "older", now.Add(-2*time.Hour))
+			oldest  = prepare(t, dir, "oldest", now.Add(-48*time.Hour))
+			unknown = filepath.Join(dir, "unknown")
+		)
+		assert.NilError(t, os.MkdirAll(unknown, 0755))
+
+		removed, err := GC(dir, 24*time.Hour, 0)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, removed, []string{oldest})
+
+		removed, err = GC(dir, 0, 2)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, removed, []string{older})
+
+		assert.Assert(t, exists(t, newest))
+		assert.Assert(t, exists(t, newer))
+		assert.Assert(t, exists(t, unknown)) // Not an execution directory.
+	})
+
+	t.Run("Directories in use are never removed", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			dir  = t.TempDir()
+			old  = time.Now().Add(-48 * time.Hour)
+			bolt = prepare(t, dir, "bolt", old)
+			jrnl = prepare(t, dir, "journal", old)
+		)
+		b1, err := BoltBackend(background, bolt)
+		assert.NilError(t, err)
+		b2, err := JournalBackend(background, jrnl)
+		assert.NilError(t, err)
+		for _, filename := range []string{
+			filepath.Join(bolt, "logs.db"),
+			filepath.Join(jrnl, "logs.journal"),
+		} {
+			assert.NilError(t, os.Chtimes(filename, old, old))
+		}
+
+		removed, err := GC(dir, time.Hour, 0)
+		assert.NilError(t, err)
+		assert.Equal(t, len(removed), 0)
+
+		// After the backends are closed, they can be removed.
+		assert.NilError(t, b1.Close())
+		assert.NilError(t, b2.Close())
+		removed, err = GC(dir, time.Hour, 0)
+		assert.NilError(t, err)
+		slices.Sort(removed)
+		assert.DeepEqual(t, removed, []string{bolt, jrnl})
+	})
+
+	t.Run("Directory whose backend cannot be opened is not removed", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			dir    = t.TempDir()
+			old    = time.Now().Add(-48 * time.Hour)
+			broken = prepare(t, dir, "broken", old)
+			ok     = prepare(t, dir, "ok", old)
+		)
+		filename := filepath.Join(broken, "logs.db")
+		assert.NilError(t, os.WriteFile(filename, []byte("broken"), 0644))
+		assert.NilError(t, os.Chtimes(filename, old, old))
+
+		removed, err := GC(dir, time.Hour, 0)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, removed, []string{ok})
+		assert.Assert(t, exists(t, broken))
+
+		// Directory moved aside for removal is not left.
+		entries, err := os.ReadDir(dir)
+		assert.NilError(t, err)
+		assert.Equal(t, len(entries), 1)
+	})
+
+	t.Run("WithRetention removes old directories when the server launches", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			dir = t.TempDir()
+			old = prepare(t, dir, "old", time.Now().Add(-48*time.Hour))
+		)
+		m, err := New(dir, WithExecutionID("retention"), WithRetention(time.Hour, 0))
+		assert.NilError(t, err)
+		t.Cleanup(m.Close)
+
+		poll.WaitOn(t, func(t poll.LogT) poll.Result {
+			if _, err := os.Stat(old); err == nil {
+				return poll.Continue("old directory is not removed yet")
+			}
+			return poll.Success()
+		})
+		_, err = m.Resource(background, "treasure")
+		assert.NilError(t, err)
+		assert.Assert(t, exists(t, filepath.Join(dir, "retention")))
+	})
+}
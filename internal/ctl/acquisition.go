@@ -2,6 +2,8 @@ package ctl
 
 import (
 	"context"
+	"slices"
+	"strings"
 	"sync"
 )
 
@@ -18,6 +20,20 @@ type (
 		Acquired int64
 		Err      error
 	}
+
+	// AcquisitionStatus is the snapshot of acquisition status.
+	AcquisitionStatus struct {
+		Max int64
+		// Operators holding the lock, sorted by operator.
+		Holders []Acquisition
+		// Operators waiting for the lock, in the order of the queue.
+		Waiters []Acquisition
+	}
+
+	Acquisition struct {
+		Operator string
+		N        int64
+	}
 )
 
 // NewAcquisitionCtl creates new AcquisitionCtl.
@@ -27,7 +43,7 @@ func NewAcquisitionCtl(max int64, acquired map[string]int64) *AcquisitionCtl {
 	// Replay acquisitions.
 	for _, n := range acquired {
 		if n > 0 {
-			<-sem.acquire(context.Background(), n, nil)
+			<-sem.acquire(context.Background(), n, "", nil)
 		}
 	}
 
@@ -46,6 +62,49 @@ func (c *AcquisitionCtl) Acquired(operator string) bool {
 	return ok
 }
 
+// Operators returns the number of operators acquiring or holding the lock.
+func (c *AcquisitionCtl) Operators() int {
+	c._m.Lock()
+	defer c._m.Unlock()
+
+	return len(c._acquired)
+}
+
+// Status returns the snapshot of holders and waiters.
+func (c *AcquisitionCtl) Status() AcquisitionStatus {
+	c._m.Lock()
+	defer c._m.Unlock()
+
+	status := AcquisitionStatus{
+		Max: c._max,
+	}
+	waiters, unsettled := c._sem.waiters()
+	for _, op := range waiters {
+		n, ok := c._acquired[op]
+		if !ok {
+			continue
+		}
+		status.Waiters = append(status.Waiters, Acquisition{
+			Operator: op,
+			N:        n,
+		})
+	}
+	for op, n := range c._acquired {
+		// Waiters including canceled ones are not holders.
+		if unsettled[op] {
+			continue
+		}
+		status.Holders = append(status.Holders, Acquisition{
+			Operator: op,
+			N:        n,
+		})
+	}
+	slices.SortFunc(status.Holders, func(a, b Acquisition) int {
+		return strings.Compare(a.Operator, b.Operator)
+	})
+	return status
+}
+
 // Acquire acquires exclusive/shared lock.
 func (c *AcquisitionCtl) Acquire(ctx context.Context, operator string, exclusive bool) (<-chan AcquisitionResult, bool) {
 	c._m.Lock()
@@ -65,7 +124,7 @@ func (c *AcquisitionCtl) Acquire(ctx context.Context, operator string, exclusive
 	// Record acquired operator.
 	c._acquired[operator] = n
 
-	return c._sem.acquire(ctx, n, func(r AcquisitionResult) {
+	return c._sem.acquire(ctx, n, operator, func(r AcquisitionResult) {
 		if r.Err != nil { // On cancel.
 			c._m.Lock()
 			delete(c._acquired, operator)
@@ -96,3 +96,65 @@ func TestAcquisitionCtl(t *testing.T) {
 		ctl.Release("alice")
 	})
 }
+
+func TestAcquisitionCtl_Status(t *testing.T) {
+	t.Parallel()
+
+	// Already, Bob and Alice have acquired shared lock.
+	c := ctl.NewAcquisitionCtl(2, map[string]int64{
+		"bob":   1,
+		"alice": 1,
+	})
+
+	// Charlie and Dave wait for the acquisition in order.
+	ctx, cancel := context.WithCancel(background)
+	defer cancel()
+	_, acquiring := c.Acquire(ctx, "charlie", true)
+	assert.Assert(t, acquiring)
+	_, acquiring = c.Acquire(ctx, "dave", false)
+	assert.Assert(t, acquiring)
+
+	assert.DeepEqual(t, c.Status(), ctl.AcquisitionStatus{
+		Max: 2,
+		Holders: []ctl.Acquisition{
+			{Operator: "alice", N: 1},
+			{Operator: "bob", N: 1},
+		},
+		Waiters: []ctl.Acquisition{
+			{Operator: "charlie", N: 2},
+			{Operator: "dave", N: 1},
+		},
+	})
+
+	// Canceled waiters are excluded.
+	cancel()
+	time.Sleep(time.Millisecond * 50)
+	assert.DeepEqual(t, c.Status().Waiters, []ctl.Acquisition(nil))
+}
+
+func TestAcquisitionCtl_Status_CanceledWaiter(t *testing.T) {
+	t.Parallel()
+
+	c := ctl.NewAcquisitionCtl(1, map[string]int64{
+		"alice": 1,
+	})
+	holders := []ctl.Acquisition{
+		{Operator: "alice", N: 1},
+	}
+
+	ctx, cancel := context.WithCancel(background)
+	acquired, acquiring := c.Acquire(ctx, "bob", true)
+	assert.Assert(t, acquiring)
+	assert.DeepEqual(t, c.Status().Holders, holders)
+
+	// Until the cancellation is settled, Bob is neither a waiter nor a holder.
+	cancel()
+	for c.Operators() > 1 {
+		status := c.Status()
+		assert.DeepEqual(t, status.Holders, holders)
+		assert.DeepEqual(t, status.Waiters, []ctl.Acquisition(nil))
+	}
+	r := <-acquired
+	assert.ErrorIs(t, r.Err, context.Canceled)
+	assert.DeepEqual(t, c.Status().Holders, holders)
+}
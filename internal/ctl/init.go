@@ -12,6 +12,7 @@ type (
 		_lock      chan struct{}
 		_m         sync.RWMutex
 		_completed bool
+		_failed    bool
 		_operator  string
 	}
 
@@ -20,6 +21,16 @@ type (
 		Initiated bool
 		Err       error
 	}
+
+	// InitState represents the state of init operation.
+	InitState int
+)
+
+const (
+	InitStateNotStarted InitState = iota
+	InitStateInProgress
+	InitStateCompleted
+	InitStateFailed
 )
 
 // NewInitCtl creates new InitCtl.
@@ -75,6 +86,7 @@ func (i *InitCtl) TryInit(ctx context.Context, operator string) <-chan TryInitRe
 
 			// Set current operator.
 			i._operator = operator
+			i._failed = false
 			try <- TryInitResult{
 				Try:       true,
 				Initiated: true,
@@ -110,6 +122,39 @@ func (i *InitCtl) Fail(operator string) error {
 		return errors.New("invalid operation")
 	}
 
+	i._failed = true
 	<-i._lock // Release.
 	return nil
 }
+
+// Reset marks init operation as not started, so that next TryInit performs init again.
+// If init operation is in progress, its lock is released forcibly, and the operator cannot complete or fail it.
+func (i *InitCtl) Reset() {
+	i._m.Lock()
+	defer i._m.Unlock()
+
+	i._completed = false
+	i._failed = false
+	i._operator = ""
+	select {
+	case <-i._lock: // Release.
+	default:
+	}
+}
+
+// Status returns the state of init operation, and the operator of the last try.
+func (i *InitCtl) Status() (InitState, string) {
+	i._m.RLock()
+	defer i._m.RUnlock()
+
+	switch {
+	case i._completed:
+		return InitStateCompleted, i._operator
+	case len(i._lock) > 0:
+		return InitStateInProgress, i._operator
+	case i._failed:
+		return InitStateFailed, i._operator
+	default:
+		return InitStateNotStarted, ""
+	}
+}
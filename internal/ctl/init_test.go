@@ -213,3 +213,66 @@ Already initialized.
 		assert.NilError(t, ctl.Complete("alice"))
 	})
 }
+
+func TestInitCtl_Status(t *testing.T) {
+	t.Parallel()
+
+	c := ctl.NewInitCtl(false)
+	state, operator := c.Status()
+	assert.Equal(t, state, ctl.InitStateNotStarted)
+	assert.Equal(t, operator, "")
+
+	result := <-c.TryInit(background, "alice")
+	assert.Assert(t, result.Initiated)
+	state, operator = c.Status()
+	assert.Equal(t, state, ctl.InitStateInProgress)
+	assert.Equal(t, operator, "alice")
+
+	assert.NilError(t, c.Fail("alice"))
+	state, operator = c.Status()
+	assert.Equal(t, state, ctl.InitStateFailed)
+	assert.Equal(t, operator, "alice")
+
+	result = <-c.TryInit(background, "bob")
+	assert.Assert(t, result.Initiated)
+	assert.NilError(t, c.Complete("bob"))
+	state, operator = c.Status()
+	assert.Equal(t, state, ctl.InitStateCompleted)
+	assert.Equal(t, operator, "bob")
+}
+
+func TestInitCtl_Reset(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Reset completed init", func(t *testing.T) {
+		t.Parallel()
+
+		c := ctl.NewInitCtl(true)
+		c.Reset()
+		state, _ := c.Status()
+		assert.Equal(t, state, ctl.InitStateNotStarted)
+
+		result := <-c.TryInit(background, "alice")
+		assert.Assert(t, result.Try && result.Initiated)
+		assert.NilError(t, c.Complete("alice"))
+	})
+
+	t.Run("Reset init in progress", func(t *testing.T) {
+		t.Parallel()
+
+		c := ctl.NewInitCtl(false)
+		result := <-c.TryInit(background, "alice")
+		assert.Assert(t, result.Initiated)
+
+		c.Reset()
+		state, operator := c.Status()
+		assert.Equal(t, state, ctl.InitStateNotStarted)
+		assert.Equal(t, operator, "")
+
+		// Former operator cannot complete init.
+		result = <-c.TryInit(background, "bob")
+		assert.Assert(t, result.Try && result.Initiated)
+		assert.Error(t, c.Complete("alice"), "invalid operation")
+		assert.NilError(t, c.Complete("bob"))
+	})
+}
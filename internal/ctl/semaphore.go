@@ -4,6 +4,7 @@ import (
 	"container/list"
 	"context"
 	"fmt"
+	"maps"
 	"sync"
 )
 
@@ -14,23 +15,27 @@ type (
 		_cur     int64
 		_mu      sync.Mutex
 		_waiters *list.List
+		// Operators queued and not acquired yet, including canceled ones until the cancellation is settled.
+		_unsettled map[string]bool
 	}
 
 	waiter struct {
-		_n     int64
-		_ready chan<- struct{}
-		_done  <-chan struct{}
+		_n        int64
+		_operator string
+		_ready    chan<- struct{}
+		_done     <-chan struct{}
 	}
 )
 
 func newSemaphore(max int64) *semaphore {
 	return &semaphore{
-		_size:    max,
-		_waiters: list.New(),
+		_size:      max,
+		_waiters:   list.New(),
+		_unsettled: map[string]bool{},
 	}
 }
 
-func (s *semaphore) acquire(ctx context.Context, n int64, hook func(r AcquisitionResult)) <-chan AcquisitionResult {
+func (s *semaphore) acquire(ctx context.Context, n int64, operator string, hook func(r AcquisitionResult)) <-chan AcquisitionResult {
 	s._mu.Lock()
 	defer s._mu.Unlock()
 
@@ -57,10 +62,12 @@ func (s *semaphore) acquire(ctx context.Context, n int64, hook func(r Acquisitio
 		done   = ctx.Done()
 	)
 	s._waiters.PushBack(waiter{
-		_n:     n,
-		_ready: ready,
-		_done:  done,
+		_n:        n,
+		_operator: operator,
+		_ready:    ready,
+		_done:     done,
 	})
+	s._unsettled[operator] = true
 
 	begin := make(chan struct{})
 	go func() {
@@ -71,6 +78,7 @@ func (s *semaphore) acquire(ctx context.Context, n int64, hook func(r Acquisitio
 			r.Err = ctx.Err()
 			defer func() {
 				s._mu.Lock()
+				delete(s._unsettled, operator) // After the hook.
 				s._notifyWaiters()
 				s._mu.Unlock()
 			}()
@@ -99,6 +107,24 @@ func (s *semaphore) release(n int64) {
 	s._notifyWaiters()
 }
 
+// Get operators waiting for acquisition in the order of the queue, and operators not acquired yet.
+// Canceled waiters are excluded from the former, but included in the latter until the cancellation is settled.
+func (s *semaphore) waiters() (operators []string, unsettled map[string]bool) {
+	s._mu.Lock()
+	defer s._mu.Unlock()
+
+	for e := s._waiters.Front(); e != nil; e = e.Next() {
+		w := e.Value.(waiter)
+		select {
+		case <-w._done:
+			continue
+		default:
+		}
+		operators = append(operators, w._operator)
+	}
+	return operators, maps.Clone(s._unsettled)
+}
+
 func (s *semaphore) _notifyWaiters() {
 LOOP:
 	for {
@@ -121,6 +147,7 @@ LOOP:
 		select {
 		case w._ready <- struct{}{}:
 			s._cur += w._n
+			delete(s._unsettled, w._operator)
 		default:
 			// Already canceled.
 		}
@@ -25,7 +25,7 @@ func TestSemaphore(t *testing.T) {
 	for i := 0; i < n; i++ {
 		i := i
 		eg.Go(func() error {
-			result := <-sem.acquire(background, int64(i), nil)
+			result := <-sem.acquire(background, int64(i), "", nil)
 			if result.Err != nil {
 				return result.Err
 			}
@@ -49,7 +49,7 @@ func TestSemaphore_Panics(t *testing.T) {
 		sem := newSemaphore(10)
 		notPanicked := func() (notPanicked bool) {
 			defer func() { recover() }()
-			sem.acquire(background, 11, nil)
+			sem.acquire(background, 11, "", nil)
 			notPanicked = true
 			return
 		}()
@@ -78,7 +78,7 @@ func TestSemaphore_Cancellation(t *testing.T) {
 	sem := newSemaphore(10)
 
 	// Alice acquires.
-	result := <-sem.acquire(background, 10, nil)
+	result := <-sem.acquire(background, 10, "", nil)
 	assert.NilError(t, result.Err)
 	assert.Assert(t, result.Acquired == 10)
 	time.AfterFunc(time.Millisecond*500, func() {
@@ -89,7 +89,7 @@ func TestSemaphore_Cancellation(t *testing.T) {
 	started := time.Now()
 	ctx, cancel := context.WithTimeout(background, time.Millisecond*200)
 	defer cancel()
-	result = <-sem.acquire(ctx, 1, nil)
+	result = <-sem.acquire(ctx, 1, "", nil)
 	assert.ErrorIs(t, result.Err, context.DeadlineExceeded)
 	assert.Assert(t, result.Acquired == 0)
 
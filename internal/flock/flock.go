@@ -0,0 +1,47 @@
+// Package flock provides advisory file locks shared between processes.
+package flock
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+// Mode is the mode of the file lock.
+type Mode int
+
+const (
+	Shared Mode = iota
+	Exclusive
+)
+
+// Lock acquires the file lock, and blocks until it is acquired or ctx is done.
+func Lock(ctx context.Context, f *os.File, mode Mode) error {
+	const interval = time.Millisecond * 10
+
+	for {
+		ok, err := TryLock(f, mode)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// TryLock tries to acquire the file lock without blocking.
+// If the lock is held by others, it returns false.
+func TryLock(f *os.File, mode Mode) (bool, error) {
+	return tryLock(f, mode)
+}
+
+// Unlock releases the file lock.
+func Unlock(f *os.File) error {
+	return unlock(f)
+}
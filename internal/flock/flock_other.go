@@ -0,0 +1,16 @@
+//go:build !unix && !windows
+
+package flock
+
+import (
+	"errors"
+	"os"
+)
+
+func tryLock(*os.File, Mode) (bool, error) {
+	return false, errors.ErrUnsupported
+}
+
+func unlock(*os.File) error {
+	return errors.ErrUnsupported
+}
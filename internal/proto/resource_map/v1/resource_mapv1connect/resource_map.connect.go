@@ -54,6 +54,18 @@ const (
 	// ResourceMapServiceReleaseMultiProcedure is the fully-qualified name of the ResourceMapService's
 	// ReleaseMulti RPC.
 	ResourceMapServiceReleaseMultiProcedure = "/internal.proto.resource_map.v1.ResourceMapService/ReleaseMulti"
+	// ResourceMapServiceWatchProcedure is the fully-qualified name of the ResourceMapService's Watch
+	// RPC.
+	ResourceMapServiceWatchProcedure = "/internal.proto.resource_map.v1.ResourceMapService/Watch"
+	// ResourceMapServiceGetStatusProcedure is the fully-qualified name of the ResourceMapService's
+	// GetStatus RPC.
+	ResourceMapServiceGetStatusProcedure = "/internal.proto.resource_map.v1.ResourceMapService/GetStatus"
+	// ResourceMapServiceForceReleaseProcedure is the fully-qualified name of the ResourceMapService's
+	// ForceRelease RPC.
+	ResourceMapServiceForceReleaseProcedure = "/internal.proto.resource_map.v1.ResourceMapService/ForceRelease"
+	// ResourceMapServiceResetInitProcedure is the fully-qualified name of the ResourceMapService's
+	// ResetInit RPC.
+	ResourceMapServiceResetInitProcedure = "/internal.proto.resource_map.v1.ResourceMapService/ResetInit"
 )
 
 // ResourceMapServiceClient is a client for the internal.proto.resource_map.v1.ResourceMapService
@@ -66,6 +78,11 @@ type ResourceMapServiceClient interface {
 	AcquireMulti(context.Context, *connect_go.Request[v1.AcquireMultiRequest]) (*connect_go.Response[v1.AcquireMultiResponse], error)
 	Release(context.Context, *connect_go.Request[v1.ReleaseRequest]) (*connect_go.Response[v1.ReleaseResponse], error)
 	ReleaseMulti(context.Context, *connect_go.Request[v1.ReleaseMultiRequest]) (*connect_go.Response[v1.ReleaseMultiResponse], error)
+	Watch(context.Context, *connect_go.Request[v1.WatchRequest]) (*connect_go.ServerStreamForClient[v1.WatchResponse], error)
+	GetStatus(context.Context, *connect_go.Request[v1.GetStatusRequest]) (*connect_go.Response[v1.GetStatusResponse], error)
+	// Admin operations.
+	ForceRelease(context.Context, *connect_go.Request[v1.ForceReleaseRequest]) (*connect_go.Response[v1.ForceReleaseResponse], error)
+	ResetInit(context.Context, *connect_go.Request[v1.ResetInitRequest]) (*connect_go.Response[v1.ResetInitResponse], error)
 }
 
 // NewResourceMapServiceClient constructs a client for the
@@ -114,6 +131,26 @@ func NewResourceMapServiceClient(httpClient connect_go.HTTPClient, baseURL strin
 			baseURL+ResourceMapServiceReleaseMultiProcedure,
 			opts...,
 		),
+		watch: connect_go.NewClient[v1.WatchRequest, v1.WatchResponse](
+			httpClient,
+			baseURL+ResourceMapServiceWatchProcedure,
+			opts...,
+		),
+		getStatus: connect_go.NewClient[v1.GetStatusRequest, v1.GetStatusResponse](
+			httpClient,
+			baseURL+ResourceMapServiceGetStatusProcedure,
+			opts...,
+		),
+		forceRelease: connect_go.NewClient[v1.ForceReleaseRequest, v1.ForceReleaseResponse](
+			httpClient,
+			baseURL+ResourceMapServiceForceReleaseProcedure,
+			opts...,
+		),
+		resetInit: connect_go.NewClient[v1.ResetInitRequest, v1.ResetInitResponse](
+			httpClient,
+			baseURL+ResourceMapServiceResetInitProcedure,
+			opts...,
+		),
 	}
 }
 
@@ -126,6 +163,10 @@ type resourceMapServiceClient struct {
 	acquireMulti         *connect_go.Client[v1.AcquireMultiRequest, v1.AcquireMultiResponse]
 	release              *connect_go.Client[v1.ReleaseRequest, v1.ReleaseResponse]
 	releaseMulti         *connect_go.Client[v1.ReleaseMultiRequest, v1.ReleaseMultiResponse]
+	watch                *connect_go.Client[v1.WatchRequest, v1.WatchResponse]
+	getStatus            *connect_go.Client[v1.GetStatusRequest, v1.GetStatusResponse]
+	forceRelease         *connect_go.Client[v1.ForceReleaseRequest, v1.ForceReleaseResponse]
+	resetInit            *connect_go.Client[v1.ResetInitRequest, v1.ResetInitResponse]
 }
 
 // TryInitResource calls internal.proto.resource_map.v1.ResourceMapService.TryInitResource.
@@ -164,6 +205,26 @@ func (c *resourceMapServiceClient) ReleaseMulti(ctx context.Context, req *connec
 	return c.releaseMulti.CallUnary(ctx, req)
 }
 
+// Watch calls internal.proto.resource_map.v1.ResourceMapService.Watch.
+func (c *resourceMapServiceClient) Watch(ctx context.Context, req *connect_go.Request[v1.WatchRequest]) (*connect_go.ServerStreamForClient[v1.WatchResponse], error) {
+	return c.watch.CallServerStream(ctx, req)
+}
+
+// GetStatus calls internal.proto.resource_map.v1.ResourceMapService.GetStatus.
+func (c *resourceMapServiceClient) GetStatus(ctx context.Context, req *connect_go.Request[v1.GetStatusRequest]) (*connect_go.Response[v1.GetStatusResponse], error) {
+	return c.getStatus.CallUnary(ctx, req)
+}
+
+// ForceRelease calls internal.proto.resource_map.v1.ResourceMapService.ForceRelease.
+func (c *resourceMapServiceClient) ForceRelease(ctx context.Context, req *connect_go.Request[v1.ForceReleaseRequest]) (*connect_go.Response[v1.ForceReleaseResponse], error) {
+	return c.forceRelease.CallUnary(ctx, req)
+}
+
+// ResetInit calls internal.proto.resource_map.v1.ResourceMapService.ResetInit.
+func (c *resourceMapServiceClient) ResetInit(ctx context.Context, req *connect_go.Request[v1.ResetInitRequest]) (*connect_go.Response[v1.ResetInitResponse], error) {
+	return c.resetInit.CallUnary(ctx, req)
+}
+
 // ResourceMapServiceHandler is an implementation of the
 // internal.proto.resource_map.v1.ResourceMapService service.
 type ResourceMapServiceHandler interface {
@@ -174,6 +235,11 @@ type ResourceMapServiceHandler interface {
 	AcquireMulti(context.Context, *connect_go.Request[v1.AcquireMultiRequest]) (*connect_go.Response[v1.AcquireMultiResponse], error)
 	Release(context.Context, *connect_go.Request[v1.ReleaseRequest]) (*connect_go.Response[v1.ReleaseResponse], error)
 	ReleaseMulti(context.Context, *connect_go.Request[v1.ReleaseMultiRequest]) (*connect_go.Response[v1.ReleaseMultiResponse], error)
+	Watch(context.Context, *connect_go.Request[v1.WatchRequest], *connect_go.ServerStream[v1.WatchResponse]) error
+	GetStatus(context.Context, *connect_go.Request[v1.GetStatusRequest]) (*connect_go.Response[v1.GetStatusResponse], error)
+	// Admin operations.
+	ForceRelease(context.Context, *connect_go.Request[v1.ForceReleaseRequest]) (*connect_go.Response[v1.ForceReleaseResponse], error)
+	ResetInit(context.Context, *connect_go.Request[v1.ResetInitRequest]) (*connect_go.Response[v1.ResetInitResponse], error)
 }
 
 // NewResourceMapServiceHandler builds an HTTP handler from the service implementation. It returns
@@ -217,6 +283,26 @@ func NewResourceMapServiceHandler(svc ResourceMapServiceHandler, opts ...connect
 		svc.ReleaseMulti,
 		opts...,
 	)
+	resourceMapServiceWatchHandler := connect_go.NewServerStreamHandler(
+		ResourceMapServiceWatchProcedure,
+		svc.Watch,
+		opts...,
+	)
+	resourceMapServiceGetStatusHandler := connect_go.NewUnaryHandler(
+		ResourceMapServiceGetStatusProcedure,
+		svc.GetStatus,
+		opts...,
+	)
+	resourceMapServiceForceReleaseHandler := connect_go.NewUnaryHandler(
+		ResourceMapServiceForceReleaseProcedure,
+		svc.ForceRelease,
+		opts...,
+	)
+	resourceMapServiceResetInitHandler := connect_go.NewUnaryHandler(
+		ResourceMapServiceResetInitProcedure,
+		svc.ResetInit,
+		opts...,
+	)
 	return "/internal.proto.resource_map.v1.ResourceMapService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case ResourceMapServiceTryInitResourceProcedure:
@@ -233,6 +319,14 @@ func NewResourceMapServiceHandler(svc ResourceMapServiceHandler, opts ...connect
 			resourceMapServiceReleaseHandler.ServeHTTP(w, r)
 		case ResourceMapServiceReleaseMultiProcedure:
 			resourceMapServiceReleaseMultiHandler.ServeHTTP(w, r)
+		case ResourceMapServiceWatchProcedure:
+			resourceMapServiceWatchHandler.ServeHTTP(w, r)
+		case ResourceMapServiceGetStatusProcedure:
+			resourceMapServiceGetStatusHandler.ServeHTTP(w, r)
+		case ResourceMapServiceForceReleaseProcedure:
+			resourceMapServiceForceReleaseHandler.ServeHTTP(w, r)
+		case ResourceMapServiceResetInitProcedure:
+			resourceMapServiceResetInitHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -269,3 +363,19 @@ func (UnimplementedResourceMapServiceHandler) Release(context.Context, *connect_
 func (UnimplementedResourceMapServiceHandler) ReleaseMulti(context.Context, *connect_go.Request[v1.ReleaseMultiRequest]) (*connect_go.Response[v1.ReleaseMultiResponse], error) {
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("internal.proto.resource_map.v1.ResourceMapService.ReleaseMulti is not implemented"))
 }
+
+func (UnimplementedResourceMapServiceHandler) Watch(context.Context, *connect_go.Request[v1.WatchRequest], *connect_go.ServerStream[v1.WatchResponse]) error {
+	return connect_go.NewError(connect_go.CodeUnimplemented, errors.New("internal.proto.resource_map.v1.ResourceMapService.Watch is not implemented"))
+}
+
+func (UnimplementedResourceMapServiceHandler) GetStatus(context.Context, *connect_go.Request[v1.GetStatusRequest]) (*connect_go.Response[v1.GetStatusResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("internal.proto.resource_map.v1.ResourceMapService.GetStatus is not implemented"))
+}
+
+func (UnimplementedResourceMapServiceHandler) ForceRelease(context.Context, *connect_go.Request[v1.ForceReleaseRequest]) (*connect_go.Response[v1.ForceReleaseResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("internal.proto.resource_map.v1.ResourceMapService.ForceRelease is not implemented"))
+}
+
+func (UnimplementedResourceMapServiceHandler) ResetInit(context.Context, *connect_go.Request[v1.ResetInitRequest]) (*connect_go.Response[v1.ResetInitResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("internal.proto.resource_map.v1.ResourceMapService.ResetInit is not implemented"))
+}
@@ -0,0 +1,23 @@
+package rsmap
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Handler discarding all records, used when no logger is specified.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+var discardLogger = slog.New(discardHandler{})
+
+func modeString(exclusive bool) string {
+	if exclusive {
+		return "exclusive"
+	}
+	return "shared"
+}
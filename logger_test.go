@@ -0,0 +1,92 @@
+package rsmap
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/poll"
+)
+
+type recordHandler struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (h *recordHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *recordHandler) Handle(_ context.Context, r slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.messages = append(h.messages, r.Message)
+	return nil
+}
+
+func (h *recordHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+func (h *recordHandler) WithGroup(string) slog.Handler      { return h }
+
+func (h *recordHandler) contains(msg string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return slices.Contains(h.messages, msg)
+}
+
+func TestWithLogger(t *testing.T) {
+	t.Parallel()
+
+	h := &recordHandler{}
+	m, err := New(t.TempDir(), WithExecutionID("logger"), WithLogger(slog.New(h)))
+	assert.NilError(t, err)
+
+	// Wait for the server.
+	poll.WaitOn(t, func(t poll.LogT) poll.Result {
+		if !h.contains("rsmap: server launched") {
+			return poll.Continue("server is not launched yet")
+		}
+		return poll.Success()
+	})
+
+	r, err := m.Resource(background, "treasure", WithInit(func(ctx context.Context) error {
+		return nil
+	}))
+	assert.NilError(t, err)
+	assert.NilError(t, r.Lock(background))
+	assert.NilError(t, r.UnlockAny())
+	m.Close()
+
+	for _, msg := range []string{
+		"rsmap: init started",
+		"rsmap: init completed",
+		"rsmap: acquiring lock",
+		"rsmap: lock acquired",
+		"rsmap: lock released",
+		"rsmap: server stopped",
+	} {
+		poll.WaitOn(t, func(t poll.LogT) poll.Result {
+			if !h.contains(msg) {
+				return poll.Continue("%q is not logged", msg)
+			}
+			return poll.Success()
+		})
+	}
+}
+
+func TestWithLogger_CompleteInitFailed(t *testing.T) {
+	t.Parallel()
+
+	h := &recordHandler{}
+	m, err := New(t.TempDir(), WithExecutionID("logger"), WithLogger(slog.New(h)))
+	assert.NilError(t, err)
+
+	// Map is closed during init, so completion of init fails.
+	_, err = m.Resource(background, "treasure", WithInit(func(ctx context.Context) error {
+		m.Close()
+		return nil
+	}))
+	assert.Assert(t, err != nil)
+	assert.Assert(t, h.contains("rsmap: failed to complete init"))
+	assert.Assert(t, !h.contains("rsmap: init completed"))
+}
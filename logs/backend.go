@@ -0,0 +1,99 @@
+package logs
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.etcd.io/bbolt"
+
+	logsv1 "github.com/daichitakahashi/rsmap/internal/proto/logs/v1"
+)
+
+// Backend is the persistent storage of the server state.
+//
+// Opening Backend also represents the leadership of the server.
+// Only one Backend for the same location can be opened at the same time,
+// and the function opening Backend blocks until the former one is closed or the context is done.
+type Backend interface {
+	InfoStore() *InfoStore
+	InitRecordStore() ResourceRecordStore[logsv1.InitRecord]
+	AcquisitionRecordStore() ResourceRecordStore[logsv1.AcquisitionRecord]
+	Close() error
+}
+
+type backend struct {
+	_info    *InfoStore
+	_init    ResourceRecordStore[logsv1.InitRecord]
+	_acquire ResourceRecordStore[logsv1.AcquisitionRecord]
+	_close   func() error
+}
+
+func (b *backend) InfoStore() *InfoStore {
+	return b._info
+}
+
+func (b *backend) InitRecordStore() ResourceRecordStore[logsv1.InitRecord] {
+	return b._init
+}
+
+func (b *backend) AcquisitionRecordStore() ResourceRecordStore[logsv1.AcquisitionRecord] {
+	return b._acquire
+}
+
+func (b *backend) Close() error {
+	return b._close()
+}
+
+// Interval of retries to open the bbolt database locked by other process.
+const boltOpenInterval = time.Millisecond * 100
+
+// OpenBoltBackend opens Backend using bbolt database file. This is the default Backend.
+// It blocks while other process opens the same file, until ctx is done.
+func OpenBoltBackend(ctx context.Context, filename string) (Backend, error) {
+	var db *bbolt.DB
+	for {
+		var err error
+		db, err = bbolt.Open(filename, 0644, &bbolt.Options{
+			Timeout: boltOpenInterval,
+		})
+		if err == nil {
+			break
+		} else if !errors.Is(err, bbolt.ErrTimeout) {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+	}
+
+	b, err := newBoltBackend(db)
+	if err != nil {
+		return nil, errors.Join(err, db.Close())
+	}
+	return b, nil
+}
+
+func newBoltBackend(db *bbolt.DB) (Backend, error) {
+	info, err := NewInfoStore(db)
+	if err != nil {
+		return nil, err
+	}
+	init, err := NewResourceRecordStore[logsv1.InitRecord](db)
+	if err != nil {
+		return nil, err
+	}
+	acquire, err := NewResourceRecordStore[logsv1.AcquisitionRecord](db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &backend{
+		_info:    info,
+		_init:    init,
+		_acquire: acquire,
+		_close:   db.Close,
+	}, nil
+}
@@ -0,0 +1,278 @@
+package logs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	logsv1 "github.com/daichitakahashi/rsmap/internal/proto/logs/v1"
+)
+
+func TestBackend(t *testing.T) {
+	t.Parallel()
+
+	backends := map[string]func(t *testing.T) func(ctx context.Context) (Backend, error){
+		"bolt": func(t *testing.T) func(ctx context.Context) (Backend, error) {
+			filename := filepath.Join(t.TempDir(), "logs.db")
+			return func(ctx context.Context) (Backend, error) {
+				return OpenBoltBackend(ctx, filename)
+			}
+		},
+		"memory": func(t *testing.T) func(ctx context.Context) (Backend, error) {
+			name := t.Name()
+			return func(ctx context.Context) (Backend, error) {
+				return OpenMemoryBackend(ctx, name)
+			}
+		},
+		"journal": func(t *testing.T) func(ctx context.Context) (Backend, error) {
+			filename := filepath.Join(t.TempDir(), "logs.journal")
+			return func(ctx context.Context) (Backend, error) {
+				return OpenJournalBackend(ctx, filename)
+			}
+		},
+	}
+
+	for name, newOpen := range backends {
+		newOpen := newOpen
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			open := newOpen(t)
+
+			b, err := open(context.Background())
+			assert.NilError(t, err)
+
+			// Store logs.
+			assert.NilError(t, b.InfoStore().PutServerLog(&logsv1.ServerLog{
+				Event:     logsv1.ServerEvent_SERVER_EVENT_LAUNCHED,
+				Addr:      "http://localhost:8080",
+				Timestamp: 1694765593803865000,
+			}))
+			assert.NilError(t, b.InitRecordStore().Put([]string{"treasure"}, func(_ string, r *logsv1.InitRecord, update bool) {
+				assert.Assert(t, !update)
+				r.Logs = append(r.Logs, &logsv1.InitLog{
+					Event:     logsv1.InitEvent_INIT_EVENT_STARTED,
+					Timestamp: 1694765621790751000,
+				})
+			}))
+			assert.NilError(t, b.AcquisitionRecordStore().Put([]string{"treasure", "precious"}, func(_ string, r *logsv1.AcquisitionRecord, update bool) {
+				assert.Assert(t, !update)
+				r.Max = 3
+				r.Logs = append(r.Logs, &logsv1.AcquisitionLog{
+					Event:     logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING,
+					Timestamp: 1694765637968901000,
+				})
+			}))
+
+			// Opening other backend is given up when the context is done.
+			timedOut, cancel := context.WithTimeout(context.Background(), time.Millisecond*200)
+			defer cancel()
+			_, err = open(timedOut)
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+			// Other backend cannot be opened until closed.
+			opened := make(chan Backend)
+			go func() {
+				b, err := open(context.Background())
+				assert.Check(t, err)
+				opened <- b
+			}()
+			select {
+			case <-opened:
+				t.Fatal("backend opened twice")
+			case <-time.After(time.Millisecond * 100):
+			}
+			assert.NilError(t, b.Close())
+
+			// Stored logs are taken over.
+			b = <-opened
+			t.Cleanup(func() {
+				_ = b.Close()
+			})
+			assert.DeepEqual(t, b.InfoStore().ServerRecord(), &logsv1.ServerRecord{
+				Logs: []*logsv1.ServerLog{
+					{
+						Event:     logsv1.ServerEvent_SERVER_EVENT_LAUNCHED,
+						Addr:      "http://localhost:8080",
+						Timestamp: 1694765593803865000,
+					},
+				},
+			}, ignoreProtoUnexported)
+
+			init, err := b.InitRecordStore().Get("treasure")
+			assert.NilError(t, err)
+			assert.DeepEqual(t, init, &logsv1.InitRecord{
+				Logs: []*logsv1.InitLog{
+					{
+						Event:     logsv1.InitEvent_INIT_EVENT_STARTED,
+						Timestamp: 1694765621790751000,
+					},
+				},
+			}, ignoreProtoUnexported)
+
+			var identifiers []string
+			assert.NilError(t, b.AcquisitionRecordStore().ForEach(func(identifier string, r *logsv1.AcquisitionRecord) error {
+				identifiers = append(identifiers, identifier)
+				assert.Equal(t, r.Max, int64(3))
+				assert.Equal(t, len(r.Logs), 1)
+				return nil
+			}))
+			assert.DeepEqual(t, identifiers, []string{"precious", "treasure"})
+		})
+	}
+}
+
+func TestOpenJournalBackend_BrokenTail(t *testing.T) {
+	t.Parallel()
+
+	filename := filepath.Join(t.TempDir(), "logs.journal")
+	b, err := OpenJournalBackend(context.Background(), filename)
+	assert.NilError(t, err)
+	assert.NilError(t, b.InitRecordStore().Put([]string{"treasure"}, func(_ string, r *logsv1.InitRecord, _ bool) {
+		r.Logs = append(r.Logs, &logsv1.InitLog{
+			Event: logsv1.InitEvent_INIT_EVENT_STARTED,
+		})
+	}))
+	assert.NilError(t, b.Close())
+
+	// Append incomplete entry, as if the process crashed while writing.
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_APPEND, 0644)
+	assert.NilError(t, err)
+	_, err = f.Write([]byte{byte(journalPut), 4, 'i', 'n'})
+	assert.NilError(t, err)
+	assert.NilError(t, f.Close())
+
+	b, err = OpenJournalBackend(context.Background(), filename)
+	assert.NilError(t, err)
+	t.Cleanup(func() {
+		_ = b.Close()
+	})
+
+	r, err := b.InitRecordStore().Get("treasure")
+	assert.NilError(t, err)
+	assert.Equal(t, len(r.Logs), 1)
+
+	// Broken entry is truncated, and succeeding entries can be replayed.
+	assert.NilError(t, b.InitRecordStore().Put([]string{"precious"}, func(_ string, r *logsv1.InitRecord, _ bool) {
+		r.Logs = append(r.Logs, &logsv1.InitLog{
+			Event: logsv1.InitEvent_INIT_EVENT_STARTED,
+		})
+	}))
+	data, err := os.ReadFile(filename)
+	assert.NilError(t, err)
+	kv := newMemoryKV()
+	for offset := 0; offset < len(data); {
+		e, n, ok := readJournalEntry(data[offset:])
+		assert.Assert(t, ok)
+		kv.apply(e)
+		offset += n
+	}
+	assert.Assert(t, kv.get(bucketInit, []byte("precious")) != nil)
+}
+
+func TestOpenJournalBackend_Delta(t *testing.T) {
+	t.Parallel()
+
+	filename := filepath.Join(t.TempDir(), "logs.journal")
+	b, err := OpenJournalBackend(context.Background(), filename)
+	assert.NilError(t, err)
+	t.Cleanup(func() {
+		_ = b.Close()
+	})
+
+	put := func(n int) int64 {
+		for i := 0; i < n; i++ {
+			assert.NilError(t, b.AcquisitionRecordStore().Put([]string{"treasure"}, func(_ string, r *logsv1.AcquisitionRecord, _ bool) {
+				r.Max = 5
+				r.Logs = append(r.Logs, &logsv1.AcquisitionLog{
+					Event:     logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED,
+					N:         1,
+					Timestamp: int64(i),
+				})
+			}))
+		}
+		info, err := os.Stat(filename)
+		assert.NilError(t, err)
+		return info.Size()
+	}
+
+	// Only the appended log is written, so the journal grows linearly.
+	first := put(100)
+	second := put(100) - first
+	assert.Assert(t, second <= first, "first=%d, second=%d", first, second)
+
+	r, err := b.AcquisitionRecordStore().Get("treasure")
+	assert.NilError(t, err)
+	assert.Equal(t, len(r.Logs), 200)
+	assert.Equal(t, r.Max, int64(5))
+}
+
+func TestOpenJournalBackend_Snapshot(t *testing.T) {
+	t.Parallel()
+
+	filename := filepath.Join(t.TempDir(), "logs.journal")
+	b, err := OpenJournalBackend(context.Background(), filename)
+	assert.NilError(t, err)
+	for i := 0; i < snapshotInterval*2; i++ {
+		assert.NilError(t, b.InitRecordStore().Put([]string{"treasure"}, func(_ string, r *logsv1.InitRecord, _ bool) {
+			r.Logs = append(r.Logs, &logsv1.InitLog{
+				Event:     logsv1.InitEvent_INIT_EVENT_STARTED,
+				Timestamp: int64(i),
+			})
+		}))
+	}
+	assert.NilError(t, b.Close())
+
+	// Snapshot is taken on replay, because it is not journaled.
+	b, err = OpenJournalBackend(context.Background(), filename)
+	assert.NilError(t, err)
+	t.Cleanup(func() {
+		_ = b.Close()
+	})
+	s := b.InitRecordStore().(*memoryRecordStore[logsv1.InitRecord, *logsv1.InitRecord])
+	_, snapshot, tail := s._kv.getWithSnapshot(s._bucketName, []byte("treasure"))
+	assert.Equal(t, snapshot.n, snapshotInterval*2)
+	assert.Equal(t, len(tail), 0)
+
+	got, err := s.GetSnapshot("treasure")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, got, &logsv1.InitRecord{
+		Logs: []*logsv1.InitLog{
+			{
+				Event:     logsv1.InitEvent_INIT_EVENT_STARTED,
+				Timestamp: snapshotInterval*2 - 1,
+			},
+		},
+	}, ignoreProtoUnexported)
+}
+
+func TestOpenMemoryBackend_Discard(t *testing.T) {
+	t.Parallel()
+
+	b, err := OpenMemoryBackend(context.Background(), t.Name())
+	assert.NilError(t, err)
+	assert.NilError(t, b.InitRecordStore().Put([]string{"treasure"}, func(_ string, r *logsv1.InitRecord, _ bool) {
+		r.Logs = append(r.Logs, &logsv1.InitLog{
+			Event: logsv1.InitEvent_INIT_EVENT_STARTED,
+		})
+	}))
+	assert.NilError(t, b.Close())
+
+	// The state is discarded after the last Backend is closed.
+	memoryMu.Lock()
+	_, ok := memoryStorages[t.Name()]
+	memoryMu.Unlock()
+	assert.Assert(t, !ok)
+
+	b, err = OpenMemoryBackend(context.Background(), t.Name())
+	assert.NilError(t, err)
+	t.Cleanup(func() {
+		_ = b.Close()
+	})
+	_, err = b.InitRecordStore().Get("treasure")
+	assert.ErrorIs(t, err, ErrRecordNotFound)
+}
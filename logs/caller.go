@@ -5,21 +5,110 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math/rand"
+	"os"
+	"runtime"
+	"slices"
 	"strings"
 
+	"github.com/rs/xid"
+	"google.golang.org/protobuf/proto"
+
 	logsv1 "github.com/daichitakahashi/rsmap/internal/proto/logs/v1"
 )
 
 type CallerContext []*logsv1.Caller
 
+// Operator is the operator of resources, such as a Resource.
+// It is identified by the globally unique ID, not by the context where it is created.
+type Operator struct {
+	ID      string
+	Context CallerContext
+}
+
+// NewOperator returns the operator created in the context, with new ID.
+func NewOperator(c CallerContext) Operator {
+	return Operator{
+		ID:      xid.New().String(),
+		Context: c,
+	}
+}
+
+// OperatorMessage is the log or the request having the operator.
+type OperatorMessage interface {
+	GetOperatorId() string
+	GetContext() []*logsv1.Caller
+}
+
+// OperatorID returns the ID of the operator of the message.
+// For messages of older versions without ID, the whole context is used instead.
+func OperatorID(m OperatorMessage) string {
+	if id := m.GetOperatorId(); id != "" {
+		return id
+	}
+	return CallerContext(m.GetContext()).String()
+}
+
+// OperatorOf returns the operator of the message, whose ID is given by [OperatorID].
+func OperatorOf(m OperatorMessage) Operator {
+	return Operator{
+		ID:      OperatorID(m),
+		Context: m.GetContext(),
+	}
+}
+
 func (c CallerContext) Append(file string, line int) CallerContext {
 	return append(c, &logsv1.Caller{
 		File: file,
 		Line: int64(line),
 		Hash: newHash(),
+		Pid:  int64(os.Getpid()),
 	})
 }
 
+// AppendFrame appends the caller of the frame, with the import path of its package.
+func (c CallerContext) AppendFrame(frame runtime.Frame) CallerContext {
+	c = c.Append(frame.File, frame.Line)
+	c[len(c)-1].Package = packagePath(frame.Function)
+	return c
+}
+
+// WithTest returns the copy of c, whose last caller has the name of the test.
+func (c CallerContext) WithTest(name string) CallerContext {
+	if len(c) == 0 {
+		return c
+	}
+	last := proto.Clone(c[len(c)-1]).(*logsv1.Caller)
+	last.Test = name
+	return append(slices.Clip(c[:len(c)-1]), last)
+}
+
+// Test returns the name of the test set to the last caller having it.
+func (c CallerContext) Test() string {
+	for i := len(c) - 1; i >= 0; i-- {
+		if c[i].Test != "" {
+			return c[i].Test
+		}
+	}
+	return ""
+}
+
+// MatchTest reports whether the test of c is the test, or its subtest.
+func (c CallerContext) MatchTest(test string) bool {
+	name := c.Test()
+	return name == test || strings.HasPrefix(name, test+"/")
+}
+
+// Get the import path of the package from the fully qualified function name,
+// such as "github.com/daichitakahashi/rsmap.(*Map).Resource".
+// Dots in the last element of the path are escaped as "%2e" in the function name.
+func packagePath(function string) string {
+	slash := strings.LastIndex(function, "/")
+	if dot := strings.Index(function[slash+1:], "."); dot >= 0 {
+		function = function[:slash+1+dot]
+	}
+	return strings.ReplaceAll(function, "%2e", ".")
+}
+
 func (c CallerContext) String() string {
 	var b strings.Builder
 	for _, caller := range c {
@@ -2,9 +2,13 @@ package logs
 
 import (
 	"fmt"
+	"os"
+	"runtime"
 	"testing"
 
 	"gotest.tools/v3/assert"
+
+	logsv1 "github.com/daichitakahashi/rsmap/internal/proto/logs/v1"
 )
 
 func TestCallers(t *testing.T) {
@@ -29,3 +33,56 @@ func TestCallers(t *testing.T) {
 
 	assert.Equal(t, c.ShortString(), fmt.Sprintf("%s->%s", oneHash, twoHash))
 }
+
+func TestOperatorID(t *testing.T) {
+	t.Parallel()
+
+	var c CallerContext
+	c = c.Append("one.go", 10)
+	a := NewOperator(c)
+	b := NewOperator(c)
+	assert.Assert(t, a.ID != b.ID)
+
+	l := &logsv1.AcquisitionLog{Context: a.Context, OperatorId: a.ID}
+	assert.Equal(t, OperatorID(l), a.ID)
+
+	// Logs recorded without ID.
+	legacy := &logsv1.InitLog{Context: CallerContext{{File: "one.go", Line: 10, Hash: "a1"}}}
+	assert.Equal(t, OperatorID(legacy), "one.go:10(a1)")
+}
+
+func TestCallerContext_Test(t *testing.T) {
+	t.Parallel()
+
+	var c CallerContext
+	c = c.AppendFrame(runtime.Frame{
+		Function: "github.com/daichitakahashi/rsmap/test/a.Test_Treasure.func1",
+		File:     "a/lock_test.go",
+		Line:     20,
+	})
+	assert.Equal(t, c[0].Package, "github.com/daichitakahashi/rsmap/test/a")
+	assert.Equal(t, c[0].Pid, int64(os.Getpid()))
+
+	withTest := c.WithTest("Test_Treasure/lock")
+	assert.Equal(t, c.Test(), "") // Not modified.
+	assert.Equal(t, withTest.Test(), "Test_Treasure/lock")
+	assert.Equal(t, withTest.String(), c.String())
+
+	assert.Assert(t, withTest.MatchTest("Test_Treasure"))
+	assert.Assert(t, withTest.MatchTest("Test_Treasure/lock"))
+	assert.Assert(t, !withTest.MatchTest("Test_Treasure/lo"))
+	assert.Assert(t, !withTest.MatchTest("Test_Precious"))
+}
+
+func TestPackagePath(t *testing.T) {
+	t.Parallel()
+
+	for function, expected := range map[string]string{
+		"github.com/daichitakahashi/rsmap.(*Map).Resource":         "github.com/daichitakahashi/rsmap",
+		"github.com/daichitakahashi/rsmap/test/a_test.TestX.func1": "github.com/daichitakahashi/rsmap/test/a_test",
+		"main.main":                    "main",
+		"gopkg.in/yaml%2ev3.Unmarshal": "gopkg.in/yaml.v3",
+	} {
+		assert.Equal(t, packagePath(function), expected, function)
+	}
+}
@@ -0,0 +1,136 @@
+package logs
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// AnomalyKind represents the kind of [Anomaly].
+type AnomalyKind string
+
+const (
+	// Lock acquired but never released.
+	AnomalyUnreleased AnomalyKind = "unreleased"
+	// Acquisition started but neither acquired nor canceled.
+	AnomalyAbandonedWait AnomalyKind = "abandoned wait"
+	// Initialization started but neither completed nor failed.
+	AnomalyUnfinishedInit AnomalyKind = "unfinished init"
+	// Number of acquired slots exceeds max parallelism.
+	AnomalyOverCapacity AnomalyKind = "over capacity"
+	// Exclusive lock overlaps other holders.
+	AnomalyExclusiveOverlap AnomalyKind = "exclusive overlap"
+	// Server launched but never stopped.
+	AnomalyServerNotStopped AnomalyKind = "server not stopped"
+)
+
+// Anomaly is the suspicious event found by [Check].
+type Anomaly struct {
+	Kind AnomalyKind
+	// Event that caused the anomaly.
+	Event   Event
+	Message string
+}
+
+// Check scans events read by [ReadEvents] for anomalies, such as locks never released.
+// Events must be of finished executions, otherwise operations in progress are reported as anomalies.
+func Check(events []Event) []Anomaly {
+	type state struct {
+		initStart *Event
+		waiting   map[string]*Event
+		holding   map[string]*Event
+	}
+	var (
+		anomalies []Anomaly
+		states    = map[string]*state{}
+		launched  *Event
+	)
+	report := func(kind AnomalyKind, e *Event, format string, args ...any) {
+		anomalies = append(anomalies, Anomaly{
+			Kind:    kind,
+			Event:   *e,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	for i := range events {
+		e := &events[i]
+		switch e.Operation {
+		case "server:launched":
+			if launched != nil {
+				report(AnomalyServerNotStopped, launched, "server %s was not stopped before the launch of %s", launched.Addr, e.Addr)
+			}
+			launched = e
+			continue
+		case "server:stopped":
+			launched = nil
+			continue
+		}
+		if e.Resource == "" {
+			continue
+		}
+
+		s, ok := states[e.Resource]
+		if !ok {
+			s = &state{
+				waiting: map[string]*Event{},
+				holding: map[string]*Event{},
+			}
+			states[e.Resource] = s
+		}
+		operator := e.OperatorID
+		switch e.Operation {
+		case "init:started":
+			s.initStart = e
+		case "init:completed", "init:failed", "init:reset":
+			s.initStart = nil
+		case "acquiring":
+			if _, ok := s.waiting[operator]; !ok {
+				s.waiting[operator] = e
+			}
+		case "acquired":
+			delete(s.waiting, operator)
+			if e.Max > 0 {
+				var overlapped []string
+				for _, holder := range sortedKeys(s.holding) {
+					if holder != operator && (e.Exclusive || s.holding[holder].Exclusive) {
+						overlapped = append(overlapped, s.holding[holder].Context.String())
+					}
+				}
+				switch {
+				case len(overlapped) > 0:
+					report(AnomalyExclusiveOverlap, e, "acquired while held by %s", strings.Join(overlapped, ", "))
+				case e.Total > e.Max:
+					report(AnomalyOverCapacity, e, "%d slots acquired, exceeding max parallelism %d", e.Total, e.Max)
+				}
+			}
+			s.holding[operator] = e
+		case "canceled":
+			delete(s.waiting, operator)
+		case "released":
+			delete(s.holding, operator)
+		}
+	}
+
+	// Operations not finished.
+	if launched != nil {
+		report(AnomalyServerNotStopped, launched, "server %s was not stopped", launched.Addr)
+	}
+	for _, resource := range sortedKeys(states) {
+		s := states[resource]
+		if s.initStart != nil {
+			report(AnomalyUnfinishedInit, s.initStart, "init was neither completed nor failed")
+		}
+		for _, operator := range sortedKeys(s.waiting) {
+			report(AnomalyAbandonedWait, s.waiting[operator], "acquisition was not completed")
+		}
+		for _, operator := range sortedKeys(s.holding) {
+			report(AnomalyUnreleased, s.holding[operator], "%d slots were not released", s.holding[operator].N)
+		}
+	}
+
+	slices.SortStableFunc(anomalies, func(a, b Anomaly) int {
+		return a.Event.Timestamp.Compare(b.Event.Timestamp)
+	})
+	return anomalies
+}
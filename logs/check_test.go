@@ -0,0 +1,101 @@
+package logs
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	type found struct {
+		Kind      AnomalyKind
+		Operation string
+		Resource  string
+		Operator  string
+	}
+	summarize := func(anomalies []Anomaly) []found {
+		var s []found
+		for _, a := range anomalies {
+			s = append(s, found{
+				Kind:      a.Kind,
+				Operation: a.Event.Operation,
+				Resource:  a.Event.Resource,
+				Operator:  a.Event.Context.ShortString(),
+			})
+		}
+		return s
+	}
+
+	t.Run("Recorded logs", func(t *testing.T) {
+		t.Parallel()
+
+		events, err := ReadEvents(prepareEvents(t), Filter{})
+		assert.NilError(t, err)
+		assert.DeepEqual(t, summarize(Check(events)), []found{
+			{Kind: AnomalyServerNotStopped, Operation: "server:launched", Operator: "a1"},
+			{Kind: AnomalyUnreleased, Operation: "acquired", Resource: "treasure", Operator: "b1"},
+		})
+	})
+
+	t.Run("Anomalies", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			server = CallerContext{{File: "server.go", Line: 1, Hash: "s1"}}
+			alice  = CallerContext{{File: "alice.go", Line: 10, Hash: "a1"}}
+			bob    = CallerContext{{File: "bob.go", Line: 20, Hash: "b1"}}
+			carol  = CallerContext{{File: "carol.go", Line: 30, Hash: "c1"}}
+			ts     int64
+		)
+		event := func(resource, operation string, n, total int64, cc CallerContext) Event {
+			ts++
+			return Event{
+				Timestamp:  time.Unix(0, ts),
+				Resource:   resource,
+				Operation:  operation,
+				N:          n,
+				Total:      total,
+				Max:        2,
+				OperatorID: cc.String(),
+				Context:    cc,
+			}
+		}
+		exclusive := func(e Event) Event {
+			e.Exclusive = true
+			return e
+		}
+		events := []Event{
+			event("", "server:launched", 0, 0, server),
+			event("", "server:launched", 0, 0, server), // Former server is not stopped.
+			event("db", "init:started", 0, 0, alice),
+			event("db", "init:completed", 0, 0, alice),
+			event("cache", "init:started", 0, 0, alice), // Not finished.
+			event("db", "acquiring", 0, 0, alice),
+			exclusive(event("db", "acquired", 2, 2, alice)),
+			event("db", "acquiring", 0, 2, bob),
+			event("db", "acquired", 1, 3, bob), // Overlaps exclusive lock of alice.
+			event("db", "released", 2, 1, alice),
+			event("db", "released", 1, 0, bob),
+			event("db", "acquired", 1, 1, alice),
+			event("db", "acquired", 1, 2, bob),
+			event("db", "acquired", 1, 3, carol), // Exceeds max parallelism.
+			event("db", "released", 1, 2, alice),
+			event("db", "released", 1, 1, bob),
+			event("db", "released", 1, 0, carol),
+			event("db", "acquiring", 0, 0, bob),
+			event("db", "canceled", 0, 0, bob),    // Gave up waiting, not abandoned.
+			event("db", "acquiring", 0, 0, carol), // Abandoned.
+			event("", "server:stopped", 0, 0, server),
+		}
+		assert.DeepEqual(t, summarize(Check(events)), []found{
+			{Kind: AnomalyServerNotStopped, Operation: "server:launched", Operator: "s1"},
+			{Kind: AnomalyUnfinishedInit, Operation: "init:started", Resource: "cache", Operator: "a1"},
+			{Kind: AnomalyExclusiveOverlap, Operation: "acquired", Resource: "db", Operator: "b1"},
+			{Kind: AnomalyOverCapacity, Operation: "acquired", Resource: "db", Operator: "c1"},
+			{Kind: AnomalyAbandonedWait, Operation: "acquiring", Resource: "db", Operator: "c1"},
+		})
+	})
+}
@@ -0,0 +1,74 @@
+package logs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResourceDiff is the difference of the resource between two executions.
+type ResourceDiff struct {
+	Resource string
+	// Statistics of the resource in each execution. Nil if the resource is not used in the execution.
+	A, B *ResourceStats
+	// Locations of the callers acquired the resource only in B, such as "a_test.go:10->b_test.go:20".
+	// Callers are compared by location, because hashes differ between executions.
+	NewCallers []string
+}
+
+// Diff compares events of two executions for each resource, in the order of resource name.
+func Diff(a, b []Event) []ResourceDiff {
+	var (
+		statsA = statsByResource(a)
+		statsB = statsByResource(b)
+		diffs  = map[string]*ResourceDiff{}
+	)
+	for _, stats := range []map[string]*ResourceStats{statsA, statsB} {
+		for resource := range stats {
+			diffs[resource] = &ResourceDiff{Resource: resource}
+		}
+	}
+
+	result := make([]ResourceDiff, 0, len(diffs))
+	for _, resource := range sortedKeys(diffs) {
+		d := diffs[resource]
+		d.A, d.B = statsA[resource], statsB[resource]
+		if d.B != nil {
+			known := map[string]bool{}
+			if d.A != nil {
+				for _, c := range d.A.Callers {
+					known[callerLocation(c.Context)] = true
+				}
+			}
+			for _, c := range d.B.Callers {
+				location := callerLocation(c.Context)
+				if !known[location] {
+					known[location] = true
+					d.NewCallers = append(d.NewCallers, location)
+				}
+			}
+		}
+		result = append(result, *d)
+	}
+	return result
+}
+
+func statsByResource(events []Event) map[string]*ResourceStats {
+	stats := Stats(events)
+	m := make(map[string]*ResourceStats, len(stats))
+	for i := range stats {
+		m[stats[i].Resource] = &stats[i]
+	}
+	return m
+}
+
+// Get the location of the callers without hashes.
+func callerLocation(c CallerContext) string {
+	var b strings.Builder
+	for _, caller := range c {
+		if b.Len() > 0 {
+			b.WriteString("->")
+		}
+		fmt.Fprintf(&b, "%s:%d", caller.File, caller.Line)
+	}
+	return b.String()
+}
@@ -0,0 +1,86 @@
+package logs
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	var (
+		alice = CallerContext{{File: "alice.go", Line: 10, Hash: "a1"}}
+		bob   = CallerContext{{File: "bob.go", Line: 20, Hash: "b1"}}
+		// Same location as alice, but different hash in another execution.
+		alice2 = CallerContext{{File: "alice.go", Line: 10, Hash: "a2"}}
+	)
+	event := func(ts int64, resource, operation string, n, total int64, wait time.Duration, cc CallerContext) Event {
+		return Event{
+			Timestamp:  time.Unix(0, ts),
+			Resource:   resource,
+			Operation:  operation,
+			N:          n,
+			Total:      total,
+			Max:        2,
+			Wait:       wait,
+			OperatorID: cc.String(),
+			Context:    cc,
+		}
+	}
+	a := []Event{
+		event(1, "db", "init:started", 0, 0, 0, alice),
+		event(2, "db", "init:completed", 0, 0, 0, alice),
+		event(3, "db", "acquired", 1, 1, 10, alice),
+		event(5, "db", "released", 1, 0, 0, alice),
+		event(6, "cache", "acquired", 1, 1, 0, alice),
+		event(7, "cache", "released", 1, 0, 0, alice),
+	}
+	b := []Event{
+		event(1, "db", "init:started", 0, 0, 0, alice2),
+		event(2, "db", "init:failed", 0, 0, 0, alice2),
+		event(3, "db", "acquired", 1, 1, 30, alice2),
+		event(4, "db", "acquired", 1, 2, 20, bob),
+		event(8, "db", "released", 1, 1, 0, alice2),
+		event(9, "db", "released", 1, 0, 0, bob),
+		event(10, "queue", "acquired", 1, 1, 0, bob),
+		event(11, "queue", "released", 1, 0, 0, bob),
+	}
+
+	type summary struct {
+		Resource     string
+		A, B         bool
+		WaitA, WaitB time.Duration
+		HoldA, HoldB time.Duration
+		InitA, InitB string
+		NewCallers   []string
+	}
+	var got []summary
+	for _, d := range Diff(a, b) {
+		s := summary{
+			Resource:   d.Resource,
+			A:          d.A != nil,
+			B:          d.B != nil,
+			NewCallers: d.NewCallers,
+		}
+		if d.A != nil {
+			s.WaitA, s.HoldA, s.InitA = d.A.TotalWait, d.A.TotalHold, d.A.InitResult
+		}
+		if d.B != nil {
+			s.WaitB, s.HoldB, s.InitB = d.B.TotalWait, d.B.TotalHold, d.B.InitResult
+		}
+		got = append(got, s)
+	}
+	assert.DeepEqual(t, got, []summary{
+		{Resource: "cache", A: true, HoldA: 1}, // Removed.
+		{
+			Resource: "db", A: true, B: true,
+			WaitA: 10, WaitB: 50,
+			HoldA: 2, HoldB: 10,
+			InitA: "init:completed", InitB: "init:failed",
+			NewCallers: []string{"bob.go:20"},
+		},
+		{Resource: "queue", B: true, HoldB: 1, NewCallers: []string{"bob.go:20"}}, // Added.
+	})
+}
@@ -0,0 +1,501 @@
+package logs
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+
+	"go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	logsv1 "github.com/daichitakahashi/rsmap/internal/proto/logs/v1"
+)
+
+type (
+	// Event is a single event recorded in logs.db, with the state of the resource computed from preceding events.
+	Event struct {
+		Timestamp time.Time
+		// Name of the resource. Empty for server events.
+		Resource string
+		// One of "server:launched", "server:stopped", "init:started", "init:completed", "init:failed", "init:reset",
+		// "acquiring", "acquired", "canceled" and "released".
+		Operation string
+		// Address of the server, for "server:launched".
+		Addr string
+		// Number of slots acquired or released by the operation.
+		N int64
+		// Total number of acquired slots of the resource after the operation.
+		Total int64
+		// Max parallelism of the resource.
+		Max int64
+		// Time spent waiting for the acquisition, for "acquired" and "canceled".
+		Wait time.Duration
+		// Whether the lock is exclusive, for "acquiring", "acquired" and "canceled".
+		// In logs recorded by older versions, which lack the mode, acquisition of all slots is regarded as exclusive.
+		Exclusive bool
+		// Whether the operation is performed by admin.
+		Admin bool
+		// ID of the operator. Empty for server events.
+		// In logs recorded by older versions, which lack the ID, the whole context is used instead.
+		OperatorID string
+		Context    CallerContext
+	}
+
+	// Filter specifies events to read.
+	Filter struct {
+		// Kinds of operations among "server", "init" and "acquire". If empty, all operations are read.
+		Operations []string
+		// Names of the resources. If empty, events of all resources are read.
+		Resources []string
+		// Name of the test set by rsmap.WithTest. Events of its subtests are also read.
+		// If empty, events of all tests are read. Server events are read regardless of this field.
+		Test string
+		// Time window of the events. If zero, it is not limited.
+		// State of the resources, such as Total, is computed from all events regardless of the window.
+		Since, Until time.Time
+	}
+
+	// Records is the set of records in logs.db, read by [ReadRecords].
+	Records struct {
+		Server *logsv1.ServerRecord
+		// Names of the resources in the order of name, unless specified.
+		Resources   []string
+		Init        map[string]*logsv1.InitRecord
+		Acquisition map[string]*logsv1.AcquisitionRecord
+	}
+
+	// ExportFormat is the format of [Export].
+	ExportFormat string
+
+	// ExportFilter specifies events to export and the format.
+	ExportFilter struct {
+		Filter
+		// Default is FormatNDJSON.
+		Format ExportFormat
+	}
+)
+
+const (
+	FormatJSON   ExportFormat = "json"
+	FormatNDJSON ExportFormat = "ndjson"
+	FormatCSV    ExportFormat = "csv"
+	// Chrome Trace Event JSON, which can be loaded into Perfetto or chrome://tracing.
+	FormatTrace ExportFormat = "trace"
+	// Mermaid sequence diagram, with participants of callers, the server and resources.
+	FormatMermaid ExportFormat = "mermaid"
+	// Self-contained HTML file showing the timeline of holders of each resource.
+	FormatHTML ExportFormat = "html"
+)
+
+func (f Filter) operation(op string) bool {
+	return len(f.Operations) == 0 || slices.Contains(f.Operations, op)
+}
+
+func (f Filter) match(e Event) bool {
+	return (f.Since.IsZero() || !e.Timestamp.Before(f.Since)) &&
+		(f.Until.IsZero() || e.Timestamp.Before(f.Until)) &&
+		(f.Test == "" || e.Resource == "" || e.Context.MatchTest(f.Test))
+}
+
+// ReadEvents reads events in logs.db in the order of timestamp.
+// The database is only read, so it can be opened read-only. Older schema versions are read without migration.
+func ReadEvents(db *bbolt.DB, filter Filter) ([]Event, error) {
+	var events []Event
+	err := db.View(func(tx *bbolt.Tx) error {
+		if err := checkSchema(tx); err != nil {
+			return err
+		}
+
+		if filter.operation("server") {
+			server, err := viewServerRecord(tx)
+			if err != nil {
+				return err
+			}
+			for _, l := range server.Logs {
+				events = append(events, serverEvent(l))
+			}
+		}
+
+		resources := filter.Resources
+		if len(resources) == 0 {
+			var err error
+			resources, err = resourceNames(tx)
+			if err != nil {
+				return err
+			}
+		}
+		if filter.operation("init") {
+			for _, resource := range resources {
+				var r logsv1.InitRecord
+				ok, err := viewRecord(tx.Bucket(bucketInit), resource, &r)
+				if err != nil {
+					return err
+				} else if !ok {
+					continue
+				}
+				for _, l := range r.Logs {
+					events = append(events, initEvent(resource, l))
+				}
+			}
+		}
+		if filter.operation("acquire") {
+			for _, resource := range resources {
+				var r logsv1.AcquisitionRecord
+				ok, err := viewRecord(tx.Bucket(bucketAcquire), resource, &r)
+				if err != nil {
+					return err
+				} else if !ok {
+					continue
+				}
+				events = append(events, acquisitionEvents(resource, &r)...)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events = slices.DeleteFunc(events, func(e Event) bool {
+		return !filter.match(e)
+	})
+	slices.SortStableFunc(events, func(a, b Event) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+	return events, nil
+}
+
+// ReadRecords reads the server record, and init and acquisition records of the resources in a single transaction.
+// If resources is empty, records of all resources are read. Resources without the record are omitted.
+// Like [ReadEvents], the database is only read, and older schema versions are read without migration.
+func ReadRecords(db *bbolt.DB, resources []string) (*Records, error) {
+	records := Records{
+		Init:        map[string]*logsv1.InitRecord{},
+		Acquisition: map[string]*logsv1.AcquisitionRecord{},
+	}
+	err := db.View(func(tx *bbolt.Tx) (err error) {
+		if err := checkSchema(tx); err != nil {
+			return err
+		}
+
+		records.Server, err = viewServerRecord(tx)
+		if err != nil {
+			return err
+		}
+		records.Resources = resources
+		if len(resources) == 0 {
+			records.Resources, err = resourceNames(tx)
+			if err != nil {
+				return err
+			}
+		}
+		for _, resource := range records.Resources {
+			var init logsv1.InitRecord
+			if ok, err := viewRecord(tx.Bucket(bucketInit), resource, &init); err != nil {
+				return err
+			} else if ok {
+				records.Init[resource] = &init
+			}
+			var acquisition logsv1.AcquisitionRecord
+			if ok, err := viewRecord(tx.Bucket(bucketAcquire), resource, &acquisition); err != nil {
+				return err
+			} else if ok {
+				records.Acquisition[resource] = &acquisition
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &records, nil
+}
+
+func resourceNames(tx *bbolt.Tx) ([]string, error) {
+	var resources []string
+	for _, name := range [][]byte{bucketInit, bucketAcquire} {
+		b := tx.Bucket(name)
+		if b == nil {
+			continue
+		}
+		err := b.ForEach(func(k, _ []byte) error {
+			if !slices.Contains(resources, string(k)) {
+				resources = append(resources, string(k))
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	slices.Sort(resources)
+	return resources, nil
+}
+
+// Read the server record without creating the bucket.
+func viewServerRecord(tx *bbolt.Tx) (*logsv1.ServerRecord, error) {
+	var r logsv1.ServerRecord
+	b := tx.Bucket(bucketInfo)
+	if b == nil {
+		return &r, nil
+	}
+	if data := b.Get(infoServerKey); data != nil {
+		if err := proto.Unmarshal(data, &r); err != nil {
+			return nil, err
+		}
+	}
+	return &r, nil
+}
+
+// Read the record of the resource having all logs, without migration.
+// Both of the nested bucket and the record stored as a single value (schema version 1) are accepted.
+// If the record is not found, ok is false.
+func viewRecord(b *bbolt.Bucket, identifier string, r protoreflect.ProtoMessage) (ok bool, err error) {
+	if b == nil {
+		return false, nil
+	}
+	if rb := b.Bucket([]byte(identifier)); rb != nil {
+		return true, readAll(rb, r)
+	}
+	if data := b.Get([]byte(identifier)); data != nil {
+		return true, proto.Unmarshal(data, r)
+	}
+	return false, nil
+}
+
+func serverEvent(l *logsv1.ServerLog) Event {
+	e := Event{
+		Timestamp: time.Unix(0, l.Timestamp),
+		Context:   l.Context,
+	}
+	switch l.Event {
+	case logsv1.ServerEvent_SERVER_EVENT_LAUNCHED:
+		e.Operation = "server:launched"
+		e.Addr = l.Addr
+	case logsv1.ServerEvent_SERVER_EVENT_STOPPED:
+		e.Operation = "server:stopped"
+	default:
+		e.Operation = l.Event.String()
+	}
+	return e
+}
+
+func initEvent(resource string, l *logsv1.InitLog) Event {
+	e := Event{
+		Timestamp:  time.Unix(0, l.Timestamp),
+		Resource:   resource,
+		Admin:      l.Admin,
+		OperatorID: OperatorID(l),
+		Context:    l.Context,
+	}
+	switch l.Event {
+	case logsv1.InitEvent_INIT_EVENT_STARTED:
+		e.Operation = "init:started"
+	case logsv1.InitEvent_INIT_EVENT_COMPLETED:
+		e.Operation = "init:completed"
+	case logsv1.InitEvent_INIT_EVENT_FAILED:
+		e.Operation = "init:failed"
+	case logsv1.InitEvent_INIT_EVENT_RESET:
+		e.Operation = "init:reset"
+	default:
+		e.Operation = l.Event.String()
+	}
+	return e
+}
+
+// Replay acquisition logs to compute total number of acquired slots and wait time.
+func acquisitionEvents(resource string, r *logsv1.AcquisitionRecord) []Event {
+	var (
+		events    = make([]Event, 0, len(r.Logs))
+		acquiring = map[string]int64{}
+		acquired  = map[string]int64{}
+		total     int64
+	)
+	for _, l := range r.Logs {
+		e := Event{
+			Timestamp:  time.Unix(0, l.Timestamp),
+			Resource:   resource,
+			Max:        r.Max,
+			Admin:      l.Admin,
+			OperatorID: OperatorID(l),
+			Context:    l.Context,
+		}
+		operator := e.OperatorID
+		switch l.Event {
+		case logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING:
+			e.Operation = "acquiring"
+			e.Exclusive = l.GetExclusive()
+			if _, ok := acquiring[operator]; !ok {
+				acquiring[operator] = l.Timestamp
+			}
+		case logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED:
+			e.Operation = "acquired"
+			if start, ok := acquiring[operator]; ok {
+				delete(acquiring, operator)
+				e.Wait = time.Duration(l.Timestamp - start)
+			}
+			total += l.N
+			acquired[operator] = l.N
+			e.N = l.N
+			if l.Exclusive != nil {
+				e.Exclusive = *l.Exclusive
+			} else {
+				e.Exclusive = l.N == r.Max
+			}
+		case logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED:
+			e.Operation = "released"
+			if n, ok := acquired[operator]; ok {
+				total -= n
+				delete(acquired, operator)
+				e.N = n
+			}
+		case logsv1.AcquisitionEvent_ACQUISITION_EVENT_CANCELED:
+			e.Operation = "canceled"
+			e.Exclusive = l.GetExclusive()
+			if start, ok := acquiring[operator]; ok {
+				delete(acquiring, operator)
+				e.Wait = time.Duration(l.Timestamp - start)
+			}
+		default:
+			e.Operation = l.Event.String()
+		}
+		e.Total = total
+		events = append(events, e)
+	}
+	return events
+}
+
+type (
+	// JSON representation of Event.
+	exportedEvent struct {
+		Timestamp  time.Time        `json:"timestamp"`
+		Resource   string           `json:"resource,omitempty"`
+		Operation  string           `json:"operation"`
+		Addr       string           `json:"addr,omitempty"`
+		N          int64            `json:"n"`
+		Total      int64            `json:"total"`
+		Max        int64            `json:"max"`
+		Wait       int64            `json:"wait_ns"`
+		Exclusive  bool             `json:"exclusive"`
+		Admin      bool             `json:"admin"`
+		OperatorID string           `json:"operator_id,omitempty"`
+		Context    []exportedCaller `json:"context"`
+	}
+
+	exportedCaller struct {
+		File    string `json:"file"`
+		Line    int64  `json:"line"`
+		Hash    string `json:"hash"`
+		PID     int64  `json:"pid,omitempty"`
+		Package string `json:"package,omitempty"`
+		Test    string `json:"test,omitempty"`
+	}
+)
+
+func newExportedEvent(e Event) exportedEvent {
+	callers := make([]exportedCaller, 0, len(e.Context))
+	for _, c := range e.Context {
+		callers = append(callers, exportedCaller{
+			File:    c.File,
+			Line:    c.Line,
+			Hash:    c.Hash,
+			PID:     c.Pid,
+			Package: c.Package,
+			Test:    c.Test,
+		})
+	}
+	return exportedEvent{
+		Timestamp:  e.Timestamp,
+		Resource:   e.Resource,
+		Operation:  e.Operation,
+		Addr:       e.Addr,
+		N:          e.N,
+		Total:      e.Total,
+		Max:        e.Max,
+		Wait:       int64(e.Wait),
+		Exclusive:  e.Exclusive,
+		Admin:      e.Admin,
+		OperatorID: e.OperatorID,
+		Context:    callers,
+	}
+}
+
+var csvHeader = []string{"timestamp", "resource", "operation", "addr", "n", "total", "max", "wait_ns", "exclusive", "admin", "operator_id", "context"}
+
+// Export writes events in logs.db to w, one event per line(or element of array for FormatJSON).
+// With FormatTrace and FormatHTML, each acquisition is written as the slices of waiting and holding, on the track of the resource.
+func Export(w io.Writer, db *bbolt.DB, filter ExportFilter) error {
+	events, err := ReadEvents(db, filter.Filter)
+	if err != nil {
+		return err
+	}
+
+	switch filter.Format {
+	case FormatNDJSON, "":
+		enc := json.NewEncoder(w)
+		for _, e := range events {
+			if err := enc.Encode(newExportedEvent(e)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormatJSON:
+		// Write an array, with one event per line.
+		sep := "["
+		for _, e := range events {
+			data, err := json.Marshal(newExportedEvent(e))
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(w, "%s\n%s", sep, data); err != nil {
+				return err
+			}
+			sep = ","
+		}
+		if len(events) == 0 {
+			_, err := io.WriteString(w, "[]\n")
+			return err
+		}
+		_, err := io.WriteString(w, "\n]\n")
+		return err
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(csvHeader); err != nil {
+			return err
+		}
+		for _, e := range events {
+			err := cw.Write([]string{
+				e.Timestamp.Format(time.RFC3339Nano),
+				e.Resource,
+				e.Operation,
+				e.Addr,
+				strconv.FormatInt(e.N, 10),
+				strconv.FormatInt(e.Total, 10),
+				strconv.FormatInt(e.Max, 10),
+				strconv.FormatInt(int64(e.Wait), 10),
+				strconv.FormatBool(e.Exclusive),
+				strconv.FormatBool(e.Admin),
+				e.OperatorID,
+				e.Context.String(),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	case FormatTrace:
+		return writeChromeTrace(w, events)
+	case FormatHTML:
+		return writeHTMLReport(w, events)
+	case FormatMermaid:
+		return writeMermaid(w, events)
+	default:
+		return fmt.Errorf("unknown format: %s", filter.Format)
+	}
+}
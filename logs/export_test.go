@@ -0,0 +1,405 @@
+package logs
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+	"gotest.tools/v3/assert"
+
+	logsv1 "github.com/daichitakahashi/rsmap/internal/proto/logs/v1"
+)
+
+func prepareEvents(t *testing.T) *bbolt.DB {
+	t.Helper()
+
+	db, err := bbolt.Open(filepath.Join(t.TempDir(), "logs.db"), 0644, nil)
+	assert.NilError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+	b, err := newBoltBackend(db)
+	assert.NilError(t, err)
+
+	var (
+		alice = []*logsv1.Caller{{File: "alice.go", Line: 10, Hash: "a1", Test: "TestTreasure/alice"}}
+		bob   = []*logsv1.Caller{{File: "bob.go", Line: 20, Hash: "b1"}}
+	)
+	assert.NilError(t, b.InfoStore().PutServerLog(&logsv1.ServerLog{
+		Event:     logsv1.ServerEvent_SERVER_EVENT_LAUNCHED,
+		Addr:      "http://127.0.0.1:8080",
+		Context:   alice,
+		Timestamp: 100,
+	}))
+	assert.NilError(t, b.InitRecordStore().Put([]string{"treasure"}, func(_ string, r *logsv1.InitRecord, _ bool) {
+		r.Logs = append(r.Logs,
+			&logsv1.InitLog{Event: logsv1.InitEvent_INIT_EVENT_STARTED, Context: alice, Timestamp: 200},
+			&logsv1.InitLog{Event: logsv1.InitEvent_INIT_EVENT_COMPLETED, Context: alice, Timestamp: 300},
+		)
+	}))
+	assert.NilError(t, b.AcquisitionRecordStore().Put([]string{"treasure"}, func(_ string, r *logsv1.AcquisitionRecord, _ bool) {
+		r.Max = 5
+		r.Logs = append(r.Logs,
+			&logsv1.AcquisitionLog{Event: logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING, Context: alice, Timestamp: 400},
+			&logsv1.AcquisitionLog{Event: logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED, N: 5, Context: alice, Timestamp: 500},
+			&logsv1.AcquisitionLog{Event: logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING, Context: bob, Timestamp: 600},
+			&logsv1.AcquisitionLog{Event: logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED, Context: alice, Timestamp: 700, Admin: true},
+			&logsv1.AcquisitionLog{Event: logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED, N: 1, Context: bob, Timestamp: 800},
+		)
+	}))
+	return db
+}
+
+func TestReadEvents(t *testing.T) {
+	t.Parallel()
+
+	db := prepareEvents(t)
+
+	events, err := ReadEvents(db, Filter{})
+	assert.NilError(t, err)
+	type summary struct {
+		Operation      string
+		N, Total, Max  int64
+		Wait           time.Duration
+		Admin          bool
+		Resource, Addr string
+	}
+	var got []summary
+	for _, e := range events {
+		got = append(got, summary{
+			Operation: e.Operation,
+			N:         e.N,
+			Total:     e.Total,
+			Max:       e.Max,
+			Wait:      e.Wait,
+			Admin:     e.Admin,
+			Resource:  e.Resource,
+			Addr:      e.Addr,
+		})
+	}
+	assert.DeepEqual(t, got, []summary{
+		{Operation: "server:launched", Addr: "http://127.0.0.1:8080"},
+		{Operation: "init:started", Resource: "treasure"},
+		{Operation: "init:completed", Resource: "treasure"},
+		{Operation: "acquiring", Max: 5, Resource: "treasure"},
+		{Operation: "acquired", N: 5, Total: 5, Max: 5, Wait: 100, Resource: "treasure"},
+		{Operation: "acquiring", Total: 5, Max: 5, Resource: "treasure"},
+		{Operation: "released", N: 5, Max: 5, Admin: true, Resource: "treasure"},
+		{Operation: "acquired", N: 1, Total: 1, Max: 5, Wait: 200, Resource: "treasure"},
+	})
+
+	// Filter by operations.
+	events, err = ReadEvents(db, Filter{
+		Operations: []string{"server", "init"},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(events), 3)
+
+	// Filter by resources.
+	events, err = ReadEvents(db, Filter{
+		Resources: []string{"unknown"},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(events), 1) // Server event only.
+
+	// Filter by test.
+	events, err = ReadEvents(db, Filter{
+		Test: "TestTreasure",
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(events), 6) // Server event, and events of alice.
+	events, err = ReadEvents(db, Filter{
+		Test: "TestTreasure/bob",
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(events), 1)
+
+	// Filter by time window.
+	events, err = ReadEvents(db, Filter{
+		Since: time.Unix(0, 200),
+		Until: time.Unix(0, 400),
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(events), 2)
+}
+
+func TestReadEvents_ReadOnly(t *testing.T) {
+	t.Parallel()
+
+	// Database of schema version 1, where each record is stored as a single value.
+	filename := filepath.Join(t.TempDir(), "logs.db")
+	db, err := bbolt.Open(filename, 0644, nil)
+	assert.NilError(t, err)
+	data, err := proto.Marshal(&logsv1.AcquisitionRecord{
+		Max: 1,
+		Logs: []*logsv1.AcquisitionLog{
+			{Event: logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED, N: 1, Timestamp: 100},
+		},
+	})
+	assert.NilError(t, err)
+	assert.NilError(t, db.Update(func(tx *bbolt.Tx) error {
+		b, err := tx.CreateBucket(bucketAcquire)
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte("treasure"), data)
+	}))
+	assert.NilError(t, db.Close())
+
+	db, err = bbolt.Open(filename, 0644, &bbolt.Options{ReadOnly: true})
+	assert.NilError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	events, err := ReadEvents(db, Filter{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(events), 1)
+	assert.Equal(t, events[0].Operation, "acquired")
+	assert.Equal(t, events[0].Resource, "treasure")
+
+	records, err := ReadRecords(db, nil)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, records.Resources, []string{"treasure"})
+	assert.Equal(t, len(records.Init), 0)
+	assert.Equal(t, records.Acquisition["treasure"].Max, int64(1))
+	assert.Equal(t, len(records.Acquisition["treasure"].Logs), 1)
+
+	s, err := Summarize(db)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, s.Resources, []string{"treasure"})
+
+	// Database is not migrated.
+	assert.NilError(t, db.View(func(tx *bbolt.Tx) error {
+		assert.Assert(t, tx.Bucket(bucketInfo) == nil)
+		assert.Assert(t, tx.Bucket(bucketAcquire).Get([]byte("treasure")) != nil)
+		return nil
+	}))
+}
+
+func TestExport(t *testing.T) {
+	t.Parallel()
+
+	db := prepareEvents(t)
+	filter := Filter{
+		Operations: []string{"acquire"},
+	}
+
+	t.Run("ndjson", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		assert.NilError(t, Export(&buf, db, ExportFilter{Filter: filter, Format: FormatNDJSON}))
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		assert.Equal(t, len(lines), 5)
+
+		var e exportedEvent
+		assert.NilError(t, json.Unmarshal([]byte(lines[1]), &e))
+		assert.DeepEqual(t, e, exportedEvent{
+			Timestamp:  time.Unix(0, 500),
+			Resource:   "treasure",
+			Operation:  "acquired",
+			N:          5,
+			Total:      5,
+			Max:        5,
+			Wait:       100,
+			Exclusive:  true,
+			OperatorID: "alice.go:10(a1)",
+			Context:    []exportedCaller{{File: "alice.go", Line: 10, Hash: "a1", Test: "TestTreasure/alice"}},
+		})
+	})
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		assert.NilError(t, Export(&buf, db, ExportFilter{Filter: filter, Format: FormatJSON}))
+		var events []exportedEvent
+		assert.NilError(t, json.Unmarshal(buf.Bytes(), &events))
+		assert.Equal(t, len(events), 5)
+		// One event per line, and brackets.
+		assert.Equal(t, strings.Count(buf.String(), "\n"), 7)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		assert.NilError(t, Export(&buf, db, ExportFilter{Filter: filter, Format: FormatCSV}))
+		records, err := csv.NewReader(&buf).ReadAll()
+		assert.NilError(t, err)
+		assert.Equal(t, len(records), 6)
+		assert.DeepEqual(t, records[0], csvHeader)
+		assert.DeepEqual(t, records[4][1:], []string{"treasure", "released", "", "5", "0", "5", "0", "false", "true", "alice.go:10(a1)", "alice.go:10(a1)"})
+	})
+
+	t.Run("Unknown format", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		assert.ErrorContains(t, Export(&buf, db, ExportFilter{Format: "xml"}), "unknown format")
+	})
+}
+
+func TestExport_Trace(t *testing.T) {
+	t.Parallel()
+
+	db := prepareEvents(t)
+
+	var buf bytes.Buffer
+	assert.NilError(t, Export(&buf, db, ExportFilter{Format: FormatTrace}))
+	var trace traceFile
+	assert.NilError(t, json.Unmarshal(buf.Bytes(), &trace))
+
+	type slice struct {
+		Name, Phase string
+		PID, TID    int
+		TS          float64
+		Dur         float64
+	}
+	var (
+		slices []slice
+		names  = map[[2]int]string{}
+	)
+	for _, e := range trace.TraceEvents {
+		switch e.Phase {
+		case "M":
+			if e.Name == "thread_name" || e.Name == "process_name" && e.PID > 0 {
+				names[[2]int{e.PID, e.TID}] = e.Args["name"].(string)
+			}
+		default:
+			var dur float64
+			if e.Dur != nil {
+				dur = *e.Dur
+			}
+			slices = append(slices, slice{Name: e.Name, Phase: e.Phase, PID: e.PID, TID: e.TID, TS: e.TS, Dur: dur})
+		}
+	}
+
+	// Resource "treasure" is the process 1, and each operator has its own thread.
+	assert.Equal(t, names[[2]int{1, 0}], "init")
+	assert.Equal(t, names[[2]int{1, 1}], "alice.go:10(a1)")
+	assert.Equal(t, names[[2]int{1, 2}], "bob.go:20(b1)")
+	assert.DeepEqual(t, slices, []slice{
+		{Name: "server:launched", Phase: "i", PID: 0, TID: 0, TS: 0.1},
+		{Name: "init", Phase: "X", PID: 1, TID: 0, TS: 0.2, Dur: 0.1},
+		{Name: "waiting", Phase: "X", PID: 1, TID: 1, TS: 0.4, Dur: 0.1},
+		{Name: "holding", Phase: "X", PID: 1, TID: 1, TS: 0.5, Dur: 0.2},
+		{Name: "waiting", Phase: "X", PID: 1, TID: 2, TS: 0.6, Dur: 0.2},
+		{Name: "holding", Phase: "B", PID: 1, TID: 2, TS: 0.8}, // Not released yet.
+	}, cmpopts.EquateApprox(0, 1e-9))
+}
+
+func TestExport_HTML(t *testing.T) {
+	t.Parallel()
+
+	db := prepareEvents(t)
+
+	var buf bytes.Buffer
+	assert.NilError(t, Export(&buf, db, ExportFilter{Format: FormatHTML}))
+
+	// Extract data embedded in the script.
+	const prefix = "const data = "
+	var line string
+	for _, l := range strings.Split(buf.String(), "\n") {
+		if l = strings.TrimSpace(l); strings.HasPrefix(l, prefix) {
+			line = strings.TrimSuffix(strings.TrimPrefix(l, prefix), ";")
+			break
+		}
+	}
+	var data reportData
+	assert.NilError(t, json.Unmarshal([]byte(line), &data))
+
+	assert.Assert(t, data.Start.Equal(time.Unix(0, 100)))
+	assert.Equal(t, data.Duration, int64(700))
+	assert.Equal(t, len(data.Resources), 1)
+	resource := data.Resources[0]
+	assert.Equal(t, resource.Name, "treasure")
+	assert.Equal(t, resource.Max, int64(5))
+
+	type span struct {
+		Kind       string
+		Start, End int64
+		Finished   bool
+	}
+	got := map[string][]span{}
+	for _, r := range resource.Rows {
+		for _, s := range r.Spans {
+			got[r.Label] = append(got[r.Label], span{Kind: s.Kind, Start: s.Start, End: s.End, Finished: s.Finished})
+		}
+	}
+	assert.DeepEqual(t, got, map[string][]span{
+		"init": {
+			{Kind: "init", Start: 100, End: 200, Finished: true},
+		},
+		"alice.go:10(a1)": {
+			{Kind: "waiting", Start: 300, End: 400, Finished: true},
+			{Kind: "holding", Start: 400, End: 600, Finished: true},
+		},
+		"bob.go:20(b1)": {
+			{Kind: "waiting", Start: 500, End: 700, Finished: true},
+			{Kind: "holding", Start: 700, End: 700}, // Not released yet.
+		},
+	})
+}
+
+func TestExport_Mermaid(t *testing.T) {
+	t.Parallel()
+
+	db := prepareEvents(t)
+
+	t.Run("All events", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		assert.NilError(t, Export(&buf, db, ExportFilter{Format: FormatMermaid}))
+		assert.Equal(t, buf.String(), `sequenceDiagram
+    participant c1 as alice.go:10(a1)
+    participant c2 as bob.go:20(b1)
+    participant server
+    participant r1 as treasure
+    Note over server: launched http://127.0.0.1:8080
+    c1->>r1: init
+    r1-->>c1: init completed
+    c1->>r1: acquire
+    activate c1
+    r1-->>c1: acquired +5 (5/5), waited 100ns
+    deactivate c1
+    c2->>r1: acquire
+    activate c2
+    server->>r1: released (admin)
+    r1-->>c2: acquired +1 (1/5), waited 200ns
+    deactivate c2
+`)
+	})
+
+	t.Run("Time window", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		assert.NilError(t, Export(&buf, db, ExportFilter{
+			Filter: Filter{
+				Since: time.Unix(0, 500),
+				Until: time.Unix(0, 700),
+			},
+			Format: FormatMermaid,
+		}))
+		// Acquisition of alice started before the window, so the activation is omitted.
+		assert.Equal(t, buf.String(), `sequenceDiagram
+    participant c1 as alice.go:10(a1)
+    participant c2 as bob.go:20(b1)
+    participant server
+    participant r1 as treasure
+    r1-->>c1: acquired +5 (5/5), waited 100ns
+    c2->>r1: acquire
+    activate c2
+`)
+	})
+}
@@ -0,0 +1,113 @@
+package logs
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/daichitakahashi/rsmap/internal/flock"
+)
+
+// OpenJournalBackend opens Backend using append-only journal file.
+// Every update is appended to the file and synced, and the state is replayed on open.
+// It blocks while other process opens the same file, until ctx is done.
+func OpenJournalBackend(ctx context.Context, filename string) (Backend, error) {
+	f, err := os.OpenFile(filename, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if err := flock.Lock(ctx, f, flock.Exclusive); err != nil {
+		return nil, errors.Join(err, f.Close())
+	}
+	closeFile := sync.OnceValue(func() error {
+		return errors.Join(
+			flock.Unlock(f),
+			f.Close(),
+		)
+	})
+
+	kv, err := replayJournal(f)
+	if err != nil {
+		return nil, errors.Join(err, closeFile())
+	}
+	kv._journal = func(entries []journalEntry) error {
+		var buf []byte
+		for _, e := range entries {
+			buf = appendJournalEntry(buf, e)
+		}
+		if _, err := f.Write(buf); err != nil {
+			return err
+		}
+		return f.Sync()
+	}
+
+	b, err := newMemoryBackend(kv, closeFile)
+	if err != nil {
+		return nil, errors.Join(err, closeFile())
+	}
+	return b, nil
+}
+
+// Entry is encoded as the operation, and length-prefixed bucket name, key and value.
+// Records are journaled as deltas, so the size of the journal is proportional to the number of logs.
+func appendJournalEntry(buf []byte, e journalEntry) []byte {
+	buf = append(buf, byte(e.op))
+	for _, data := range [][]byte{e.bucket, e.key, e.value} {
+		buf = binary.AppendUvarint(buf, uint64(len(data)))
+		buf = append(buf, data...)
+	}
+	return buf
+}
+
+func readJournalEntry(data []byte) (journalEntry, int, bool) {
+	if len(data) == 0 || journalOp(data[0]) > journalAppend {
+		return journalEntry{}, 0, false
+	}
+	var (
+		op     = journalOp(data[0])
+		fields [3][]byte
+		offset = 1
+	)
+	for i := range fields {
+		l, n := binary.Uvarint(data[offset:])
+		if n <= 0 || uint64(len(data[offset+n:])) < l {
+			return journalEntry{}, 0, false
+		}
+		offset += n
+		fields[i] = data[offset : offset+int(l)]
+		offset += int(l)
+	}
+	return journalEntry{
+		op:     op,
+		bucket: fields[0],
+		key:    fields[1],
+		value:  fields[2],
+	}, offset, true
+}
+
+// Replay all entries in the journal.
+// Incomplete entry at the tail, caused by the crash while writing, is truncated.
+func replayJournal(f *os.File) (*memoryKV, error) {
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+
+	kv := newMemoryKV()
+	var offset int
+	for offset < len(data) {
+		e, n, ok := readJournalEntry(data[offset:])
+		if !ok {
+			if err := f.Truncate(int64(offset)); err != nil {
+				return nil, err
+			}
+			break
+		}
+		kv.apply(e)
+		offset += n
+	}
+	return kv, nil
+}
@@ -1,7 +1,9 @@
 package logs
 
 import (
+	"encoding/binary"
 	"errors"
+	"slices"
 
 	"go.etcd.io/bbolt"
 	"google.golang.org/protobuf/proto"
@@ -18,16 +20,20 @@ var (
 	infoServerKey = []byte("server")
 )
 
+// InfoStore stores the information of the server.
 type InfoStore struct {
-	_db *bbolt.DB
-
 	_server *logsv1.ServerRecord
+	// Store the serialized server record to the backend.
+	_put func(data []byte) error
 }
 
 func NewInfoStore(db *bbolt.DB) (*InfoStore, error) {
 	var server logsv1.ServerRecord
 
 	err := db.Update(func(tx *bbolt.Tx) error {
+		if err := migrate(tx); err != nil {
+			return err
+		}
 		b, err := tx.CreateBucketIfNotExists(bucketInfo)
 		if err != nil {
 			return err
@@ -48,8 +54,12 @@ func NewInfoStore(db *bbolt.DB) (*InfoStore, error) {
 	}
 
 	return &InfoStore{
-		_db:     db,
 		_server: &server,
+		_put: func(data []byte) error {
+			return db.Update(func(tx *bbolt.Tx) error {
+				return tx.Bucket(bucketInfo).Put(infoServerKey, data)
+			})
+		},
 	}, nil
 }
 
@@ -58,21 +68,28 @@ func (s *InfoStore) ServerRecord() *logsv1.ServerRecord {
 }
 
 func (s *InfoStore) PutServerLog(l *logsv1.ServerLog) error {
-	return s._db.Update(func(tx *bbolt.Tx) error {
-		s._server.Logs = append(s._server.Logs, l)
-		data, err := proto.Marshal(s._server)
-		if err != nil {
-			return err
-		}
-		return tx.Bucket(bucketInfo).Put(infoServerKey, data)
-	})
+	s._server.Logs = append(s._server.Logs, l)
+	data, err := proto.Marshal(s._server)
+	if err != nil {
+		return err
+	}
+	return s._put(data)
 }
 
 type (
+	// ResourceRecordStore stores the logs of each resource.
 	ResourceRecordStore[T any] interface {
+		// Get returns the record having all logs of the resource.
 		Get(identifier string) (*T, error)
+		// Put updates records of the resources atomically.
+		// Logs of the record passed to update may not contain stored logs, so update must only append new logs.
 		Put(identifiers []string, update func(identifier string, r *T, update bool)) error
+		// ForEach calls fn with the record having all logs, for each resource.
 		ForEach(fn func(identifier string, record *T) error) error
+		// GetSnapshot returns the record having only logs required to restore the current state of the resource.
+		GetSnapshot(identifier string) (*T, error)
+		// ForEachSnapshot calls fn with the snapshot of the record, for each resource.
+		ForEachSnapshot(fn func(identifier string, record *T) error) error
 	}
 
 	ptr[L logsv1.InitRecord | logsv1.AcquisitionRecord] interface {
@@ -80,6 +97,8 @@ type (
 		protoreflect.ProtoMessage
 	}
 
+	// Each resource has its own nested bucket, that contains the record without logs, logs keyed by sequence number,
+	// and the snapshot taken periodically. So, appending log costs constant time regardless of the number of logs.
 	recordStore[T logsv1.InitRecord | logsv1.AcquisitionRecord, P ptr[T]] struct {
 		_bucketName []byte
 		_db         *bbolt.DB
@@ -88,20 +107,38 @@ type (
 
 var ErrRecordNotFound = errors.New("record not found on key value store")
 
-func NewResourceRecordStore[T logsv1.InitRecord | logsv1.AcquisitionRecord, P ptr[T]](db *bbolt.DB) (ResourceRecordStore[T], error) {
+var (
+	recordKey      = []byte("record")
+	bucketLogs     = []byte("logs")
+	snapshotKey    = []byte("snapshot")
+	snapshotSeqKey = []byte("snapshot_seq")
+)
+
+// Take snapshot every time the number of logs of the resource reaches the multiple of this.
+const snapshotInterval = 100
+
+// Get bucket name for the type of record.
+func bucketName[T logsv1.InitRecord | logsv1.AcquisitionRecord, P ptr[T]]() []byte {
 	var (
-		p          P                         = new(T)
-		v          protoreflect.ProtoMessage = p
-		bucketName []byte
+		p P                         = new(T)
+		v protoreflect.ProtoMessage = p
 	)
 	switch v.(type) {
 	case *logsv1.InitRecord:
-		bucketName = bucketInit
+		return bucketInit
 	case *logsv1.AcquisitionRecord:
-		bucketName = bucketAcquire
+		return bucketAcquire
 	}
+	return nil // Impossible path.
+}
+
+func NewResourceRecordStore[T logsv1.InitRecord | logsv1.AcquisitionRecord, P ptr[T]](db *bbolt.DB) (ResourceRecordStore[T], error) {
+	bucketName := bucketName[T, P]()
 
 	err := db.Update(func(tx *bbolt.Tx) error {
+		if err := migrate(tx); err != nil {
+			return err
+		}
 		// Create bucket for records.
 		_, err := tx.CreateBucketIfNotExists(bucketName)
 		return err
@@ -115,14 +152,149 @@ func NewResourceRecordStore[T logsv1.InitRecord | logsv1.AcquisitionRecord, P pt
 	}, err
 }
 
-func (s *recordStore[T, P]) Get(identifier string) (*T, error) {
+// Convert records stored as a single value into nested buckets.
+func migrateRecords[T logsv1.InitRecord | logsv1.AcquisitionRecord, P ptr[T]](b *bbolt.Bucket) error {
+	legacy := map[string][]byte{}
+	err := b.ForEach(func(k, v []byte) error {
+		if v != nil { // Not a nested bucket.
+			legacy[string(k)] = slices.Clone(v)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for identifier, data := range legacy {
+		var r P = new(T)
+		if err := proto.Unmarshal(data, r); err != nil {
+			return err
+		}
+		if err := b.Delete([]byte(identifier)); err != nil {
+			return err
+		}
+		rb, err := b.CreateBucket([]byte(identifier))
+		if err != nil {
+			return err
+		}
+		if err := appendRecord(rb, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func seqKey(seq uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, seq)
+}
+
+// Append logs of r to the bucket of the resource, and store r without logs.
+func appendRecord(b *bbolt.Bucket, r protoreflect.ProtoMessage) error {
+	lb, err := b.CreateBucketIfNotExists(bucketLogs)
+	if err != nil {
+		return err
+	}
+	last := lb.Sequence()
+
+	ls := recordLogs(r)
+	for i := 0; i < ls.Len(); i++ {
+		seq, err := lb.NextSequence()
+		if err != nil {
+			return err
+		}
+		data, err := proto.Marshal(ls.Get(i).Message().Interface())
+		if err != nil {
+			return err
+		}
+		if err := lb.Put(seqKey(seq), data); err != nil {
+			return err
+		}
+	}
+
+	header := proto.Clone(r)
+	clearLogs(header)
+	data, err := proto.Marshal(header)
+	if err != nil {
+		return err
+	}
+	if err := b.Put(recordKey, data); err != nil {
+		return err
+	}
+
+	// Take snapshot periodically.
+	if last/snapshotInterval == lb.Sequence()/snapshotInterval {
+		return nil
+	}
+	snapshot := header.ProtoReflect().New().Interface()
+	if err := readSnapshot(b, snapshot); err != nil {
+		return err
+	}
+	data, err = proto.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	if err := b.Put(snapshotKey, data); err != nil {
+		return err
+	}
+	return b.Put(snapshotSeqKey, seqKey(lb.Sequence()))
+}
+
+// Read the record and its logs after the sequence number from the bucket of the resource.
+func readRecord(b *bbolt.Bucket, r protoreflect.ProtoMessage, after uint64) error {
+	if err := proto.Unmarshal(b.Get(recordKey), r); err != nil {
+		return err
+	}
+	lb := b.Bucket(bucketLogs)
+	if lb == nil {
+		return nil
+	}
+
+	ls := recordLogs(r)
+	c := lb.Cursor()
+	for k, v := c.Seek(seqKey(after + 1)); k != nil; k, v = c.Next() {
+		l := ls.NewElement()
+		if err := proto.Unmarshal(v, l.Message().Interface()); err != nil {
+			return err
+		}
+		ls.Append(l)
+	}
+	return nil
+}
+
+// Read the last snapshot and logs after it, and compact them into the snapshot of current state.
+func readSnapshot(b *bbolt.Bucket, r protoreflect.ProtoMessage) error {
+	var after uint64
+	if data := b.Get(snapshotKey); data != nil {
+		if err := proto.Unmarshal(data, r); err != nil {
+			return err
+		}
+		after = binary.BigEndian.Uint64(b.Get(snapshotSeqKey))
+	}
+	logs := recordLogs(r)
+
+	latest := r.ProtoReflect().New().Interface()
+	if err := readRecord(b, latest, after); err != nil {
+		return err
+	}
+	tail := recordLogs(latest)
+	for i := 0; i < tail.Len(); i++ {
+		logs.Append(tail.Get(i))
+	}
+	// Take over the latest record without logs.
+	clearLogs(latest)
+	proto.Merge(r, latest)
+	compact(r)
+	return nil
+}
+
+func (s *recordStore[T, P]) get(identifier string, read func(b *bbolt.Bucket, r protoreflect.ProtoMessage) error) (*T, error) {
 	var r P = new(T)
 	err := s._db.View(func(tx *bbolt.Tx) error {
-		data := tx.Bucket(s._bucketName).Get([]byte(identifier))
-		if data == nil {
+		b := tx.Bucket(s._bucketName).Bucket([]byte(identifier))
+		if b == nil {
 			return ErrRecordNotFound
 		}
-		return proto.Unmarshal(data, r)
+		return read(b, r)
 	})
 	if err != nil {
 		return nil, err
@@ -130,33 +302,44 @@ func (s *recordStore[T, P]) Get(identifier string) (*T, error) {
 	return r, nil
 }
 
+func readAll(b *bbolt.Bucket, r protoreflect.ProtoMessage) error {
+	return readRecord(b, r, 0)
+}
+
+func (s *recordStore[T, P]) Get(identifier string) (*T, error) {
+	return s.get(identifier, readAll)
+}
+
+func (s *recordStore[T, P]) GetSnapshot(identifier string) (*T, error) {
+	return s.get(identifier, readSnapshot)
+}
+
 func (s *recordStore[T, P]) Put(identifiers []string, fn func(identifier string, r *T, update bool)) error {
 	return s._db.Update(func(tx *bbolt.Tx) error {
 		for _, identifier := range identifiers {
 			var (
-				b   = tx.Bucket(s._bucketName)
-				key = []byte(identifier)
+				b     = tx.Bucket(s._bucketName)
+				key   = []byte(identifier)
+				r   P = new(T)
 			)
 
-			var (
-				r      P = new(T)
-				update bool
-			)
-			data := b.Get(key)
-			if data != nil {
-				update = true
-				if err := proto.Unmarshal(data, r); err != nil {
+			// Only the record without logs is read.
+			rb := b.Bucket(key)
+			update := rb != nil
+			if update {
+				if err := proto.Unmarshal(rb.Get(recordKey), r); err != nil {
+					return err
+				}
+			} else {
+				var err error
+				rb, err = b.CreateBucket(key)
+				if err != nil {
 					return err
 				}
 			}
 
 			fn(identifier, r, update)
-			newData, err := proto.Marshal(r)
-			if err != nil {
-				return err
-			}
-			err = b.Put(key, newData)
-			if err != nil {
+			if err := appendRecord(rb, r); err != nil {
 				return err
 			}
 		}
@@ -164,15 +347,23 @@ func (s *recordStore[T, P]) Put(identifiers []string, fn func(identifier string,
 	})
 }
 
-func (s *recordStore[T, P]) ForEach(fn func(identifier string, record *T) error) error {
+func (s *recordStore[T, P]) forEach(fn func(identifier string, record *T) error, read func(b *bbolt.Bucket, r protoreflect.ProtoMessage) error) error {
 	return s._db.View(func(tx *bbolt.Tx) error {
-		return tx.Bucket(s._bucketName).ForEach(func(k, v []byte) error {
+		b := tx.Bucket(s._bucketName)
+		return b.ForEach(func(k, _ []byte) error {
 			var r P = new(T)
-			err := proto.Unmarshal(v, r)
-			if err != nil {
+			if err := read(b.Bucket(k), r); err != nil {
 				return err
 			}
 			return fn(string(k), r)
 		})
 	})
 }
+
+func (s *recordStore[T, P]) ForEach(fn func(identifier string, record *T) error) error {
+	return s.forEach(fn, readAll)
+}
+
+func (s *recordStore[T, P]) ForEachSnapshot(fn func(identifier string, record *T) error) error {
+	return s.forEach(fn, readSnapshot)
+}
@@ -1,12 +1,14 @@
 package logs
 
 import (
+	"context"
 	"math"
 	"path/filepath"
 	"testing"
 
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
 	"gotest.tools/v3/assert"
 
 	logsv1 "github.com/daichitakahashi/rsmap/internal/proto/logs/v1"
@@ -334,3 +336,245 @@ func TestResourceRecordStore(t *testing.T) {
 		}),
 	)
 }
+
+func TestResourceRecordStore_Snapshot(t *testing.T) {
+	t.Parallel()
+
+	stores := map[string]func(t *testing.T) ResourceRecordStore[logsv1.AcquisitionRecord]{
+		"bolt": func(t *testing.T) ResourceRecordStore[logsv1.AcquisitionRecord] {
+			db, err := bbolt.Open(filepath.Join(t.TempDir(), "records.db"), 0644, nil)
+			assert.NilError(t, err)
+			t.Cleanup(func() {
+				_ = db.Close()
+			})
+			store, err := NewResourceRecordStore[logsv1.AcquisitionRecord](db)
+			assert.NilError(t, err)
+			return store
+		},
+		"memory": func(t *testing.T) ResourceRecordStore[logsv1.AcquisitionRecord] {
+			b, err := OpenMemoryBackend(context.Background(), t.Name())
+			assert.NilError(t, err)
+			t.Cleanup(func() {
+				_ = b.Close()
+			})
+			return b.AcquisitionRecordStore()
+		},
+		"journal": func(t *testing.T) ResourceRecordStore[logsv1.AcquisitionRecord] {
+			b, err := OpenJournalBackend(context.Background(), filepath.Join(t.TempDir(), "logs.journal"))
+			assert.NilError(t, err)
+			t.Cleanup(func() {
+				_ = b.Close()
+			})
+			return b.AcquisitionRecordStore()
+		},
+	}
+
+	for name, newStore := range stores {
+		newStore := newStore
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			store := newStore(t)
+			var (
+				caller = func(name string) []*logsv1.Caller {
+					return []*logsv1.Caller{{File: name + ".go", Line: 1, Hash: name}}
+				}
+				put = func(event logsv1.AcquisitionEvent, operator string, n int64) {
+					t.Helper()
+					assert.NilError(t, store.Put([]string{"treasure"}, func(_ string, r *logsv1.AcquisitionRecord, update bool) {
+						if !update {
+							r.Max = 5
+						}
+						r.Logs = append(r.Logs, &logsv1.AcquisitionLog{
+							Event:   event,
+							N:       n,
+							Context: caller(operator),
+						})
+					}))
+				}
+			)
+
+			// Acquire and release repeatedly, over the interval of snapshot.
+			for i := 0; i < snapshotInterval; i++ {
+				put(logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING, "alice", 0)
+				put(logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED, "alice", 5)
+				put(logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED, "alice", 0)
+			}
+			put(logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING, "bob", 0)
+			put(logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING, "carol", 0)
+			put(logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED, "bob", 1)
+			put(logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING, "dave", 0)
+			put(logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING, "erin", 0)
+			put(logsv1.AcquisitionEvent_ACQUISITION_EVENT_CANCELED, "erin", 0)
+
+			expected := &logsv1.AcquisitionRecord{
+				Max: 5,
+				Logs: []*logsv1.AcquisitionLog{
+					{Event: logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING, Context: caller("carol")},
+					{Event: logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED, N: 1, Context: caller("bob")},
+					{Event: logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING, Context: caller("dave")},
+				},
+			}
+			got, err := store.GetSnapshot("treasure")
+			assert.NilError(t, err)
+			assert.DeepEqual(t, got, expected, ignoreProtoUnexported)
+
+			assert.NilError(t, store.ForEachSnapshot(func(identifier string, r *logsv1.AcquisitionRecord) error {
+				assert.Equal(t, identifier, "treasure")
+				assert.DeepEqual(t, r, expected, ignoreProtoUnexported)
+				return nil
+			}))
+
+			// All logs are still available.
+			got, err = store.Get("treasure")
+			assert.NilError(t, err)
+			assert.Equal(t, got.Max, int64(5))
+			assert.Equal(t, len(got.Logs), snapshotInterval*3+6)
+
+			// Snapshot on memory is read with the logs after it, not with all logs.
+			if s, ok := store.(*memoryRecordStore[logsv1.AcquisitionRecord, *logsv1.AcquisitionRecord]); ok {
+				_, _, tail := s._kv.getWithSnapshot(s._bucketName, []byte("treasure"))
+				assert.Assert(t, len(tail) < snapshotInterval, "%d logs after the snapshot", len(tail))
+			}
+		})
+	}
+}
+
+func TestNewResourceRecordStore_Migration(t *testing.T) {
+	t.Parallel()
+
+	db, err := bbolt.Open(filepath.Join(t.TempDir(), "records.db"), 0644, nil)
+	assert.NilError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	// Store the record as a single value, as former versions did.
+	legacy := &logsv1.AcquisitionRecord{
+		Max: 3,
+		Logs: []*logsv1.AcquisitionLog{
+			{Event: logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING, Timestamp: 1},
+			{Event: logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED, N: 1, Timestamp: 2},
+		},
+	}
+	assert.NilError(t, db.Update(func(tx *bbolt.Tx) error {
+		b, err := tx.CreateBucket(bucketAcquire)
+		if err != nil {
+			return err
+		}
+		data, err := proto.Marshal(legacy)
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte("treasure"), data)
+	}))
+
+	store, err := NewResourceRecordStore[logsv1.AcquisitionRecord](db)
+	assert.NilError(t, err)
+	got, err := store.Get("treasure")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, got, legacy, ignoreProtoUnexported)
+
+	// Migrated record can be updated.
+	assert.NilError(t, store.Put([]string{"treasure"}, func(_ string, r *logsv1.AcquisitionRecord, update bool) {
+		assert.Assert(t, update)
+		assert.Equal(t, r.Max, int64(3))
+		r.Logs = append(r.Logs, &logsv1.AcquisitionLog{
+			Event:     logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED,
+			Timestamp: 3,
+		})
+	}))
+	got, err = store.Get("treasure")
+	assert.NilError(t, err)
+	assert.Equal(t, len(got.Logs), 3)
+}
+
+func TestSchemaVersion(t *testing.T) {
+	t.Parallel()
+
+	openDB := func(t *testing.T) *bbolt.DB {
+		t.Helper()
+
+		db, err := bbolt.Open(filepath.Join(t.TempDir(), "records.db"), 0644, nil)
+		assert.NilError(t, err)
+		t.Cleanup(func() {
+			_ = db.Close()
+		})
+		return db
+	}
+	version := func(t *testing.T, db *bbolt.DB) string {
+		t.Helper()
+
+		var v string
+		assert.NilError(t, db.View(func(tx *bbolt.Tx) error {
+			v = string(tx.Bucket(bucketInfo).Get(infoVersionKey))
+			return nil
+		}))
+		return v
+	}
+
+	t.Run("New database has the current version", func(t *testing.T) {
+		t.Parallel()
+
+		db := openDB(t)
+		_, err := NewInfoStore(db)
+		assert.NilError(t, err)
+		assert.Equal(t, version(t, db), "2")
+		assert.NilError(t, CheckSchema(db))
+	})
+
+	t.Run("Database without version is migrated", func(t *testing.T) {
+		t.Parallel()
+
+		db := openDB(t)
+		data, err := proto.Marshal(&logsv1.InitRecord{
+			Logs: []*logsv1.InitLog{
+				{Event: logsv1.InitEvent_INIT_EVENT_STARTED, Timestamp: 1},
+			},
+		})
+		assert.NilError(t, err)
+		assert.NilError(t, db.Update(func(tx *bbolt.Tx) error {
+			b, err := tx.CreateBucket(bucketInit)
+			if err != nil {
+				return err
+			}
+			return b.Put([]byte("treasure"), data)
+		}))
+		assert.NilError(t, CheckSchema(db))
+
+		// Records of all types are migrated by any store.
+		_, err = NewInfoStore(db)
+		assert.NilError(t, err)
+		assert.Equal(t, version(t, db), "2")
+		assert.NilError(t, db.View(func(tx *bbolt.Tx) error {
+			assert.Assert(t, tx.Bucket(bucketInit).Bucket([]byte("treasure")) != nil)
+			return nil
+		}))
+
+		store, err := NewResourceRecordStore[logsv1.InitRecord](db)
+		assert.NilError(t, err)
+		r, err := store.Get("treasure")
+		assert.NilError(t, err)
+		assert.Equal(t, len(r.Logs), 1)
+	})
+
+	t.Run("Database of the newer version is refused", func(t *testing.T) {
+		t.Parallel()
+
+		db := openDB(t)
+		assert.NilError(t, db.Update(func(tx *bbolt.Tx) error {
+			b, err := tx.CreateBucket(bucketInfo)
+			if err != nil {
+				return err
+			}
+			return b.Put(infoVersionKey, []byte("3"))
+		}))
+
+		assert.ErrorIs(t, CheckSchema(db), ErrIncompatibleSchema)
+		_, err := NewInfoStore(db)
+		assert.ErrorIs(t, err, ErrIncompatibleSchema)
+		_, err = NewResourceRecordStore[logsv1.AcquisitionRecord](db)
+		assert.ErrorIs(t, err, ErrIncompatibleSchema)
+		assert.Equal(t, version(t, db), "3") // Not modified.
+	})
+}
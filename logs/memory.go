@@ -0,0 +1,488 @@
+package logs
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	logsv1 "github.com/daichitakahashi/rsmap/internal/proto/logs/v1"
+)
+
+type (
+	// Key value store on memory, which has the same bucket layout as bbolt database.
+	// Logs of the record are appended to the key separately, not to rewrite them on every update.
+	memoryKV struct {
+		_mu        sync.RWMutex
+		_buckets   map[string]map[string][]byte
+		_logs      map[string]map[string][][]byte
+		_snapshots map[string]map[string]memorySnapshot
+		_journal   func(entries []journalEntry) error
+	}
+
+	// Snapshot of the record compacted from the first n logs of the key.
+	// It is not journaled, because it can be restored from the logs.
+	memorySnapshot struct {
+		data []byte
+		n    int
+	}
+
+	journalEntry struct {
+		op     journalOp
+		bucket []byte
+		key    []byte
+		value  []byte
+	}
+
+	journalOp byte
+
+	// Pending update of the bucket, applied atomically by memoryKV.update.
+	memoryUpdate struct {
+		_kv        *memoryKV
+		_bucket    []byte
+		_pending   map[string][]byte
+		_entries   []journalEntry
+		_snapshots map[string]memorySnapshot
+	}
+)
+
+const (
+	// Put the value of the key.
+	journalPut journalOp = iota
+	// Append the log to the key.
+	journalAppend
+)
+
+func newMemoryKV() *memoryKV {
+	return &memoryKV{
+		_buckets:   map[string]map[string][]byte{},
+		_logs:      map[string]map[string][][]byte{},
+		_snapshots: map[string]map[string]memorySnapshot{},
+	}
+}
+
+func (kv *memoryKV) get(bucket, key []byte) []byte {
+	kv._mu.RLock()
+	defer kv._mu.RUnlock()
+
+	return kv._buckets[string(bucket)][string(key)]
+}
+
+// Get the value of the key and the logs appended to it.
+func (kv *memoryKV) getWithLogs(bucket, key []byte) ([]byte, [][]byte) {
+	kv._mu.RLock()
+	defer kv._mu.RUnlock()
+
+	// Appended logs never modify the returned slice.
+	return kv._buckets[string(bucket)][string(key)], slices.Clip(kv._logs[string(bucket)][string(key)])
+}
+
+// Get the value of the key, its snapshot and the logs appended after the snapshot.
+func (kv *memoryKV) getWithSnapshot(bucket, key []byte) ([]byte, memorySnapshot, [][]byte) {
+	kv._mu.RLock()
+	defer kv._mu.RUnlock()
+
+	snapshot := kv._snapshots[string(bucket)][string(key)]
+	ls := kv._logs[string(bucket)][string(key)]
+	return kv._buckets[string(bucket)][string(key)], snapshot, slices.Clip(ls[snapshot.n:])
+}
+
+// Update values in the bucket atomically. If journal is set, the values are written to it before applied.
+func (kv *memoryKV) update(bucket []byte, fn func(u *memoryUpdate) error) error {
+	kv._mu.Lock()
+	defer kv._mu.Unlock()
+
+	u := &memoryUpdate{
+		_kv:        kv,
+		_bucket:    bucket,
+		_pending:   map[string][]byte{},
+		_snapshots: map[string]memorySnapshot{},
+	}
+	if err := fn(u); err != nil {
+		return err
+	}
+	if kv._journal != nil && len(u._entries) > 0 {
+		if err := kv._journal(u._entries); err != nil {
+			return err
+		}
+	}
+	for _, e := range u._entries {
+		kv.apply(e)
+	}
+	if len(u._snapshots) > 0 {
+		b, ok := kv._snapshots[string(bucket)]
+		if !ok {
+			b = map[string]memorySnapshot{}
+			kv._snapshots[string(bucket)] = b
+		}
+		for key, snapshot := range u._snapshots {
+			b[key] = snapshot
+		}
+	}
+	return nil
+}
+
+func (u *memoryUpdate) get(key []byte) []byte {
+	if v, ok := u._pending[string(key)]; ok {
+		return v
+	}
+	return u._kv._buckets[string(u._bucket)][string(key)]
+}
+
+func (u *memoryUpdate) put(key, value []byte) {
+	u._pending[string(key)] = value
+	u._entries = append(u._entries, journalEntry{
+		op:     journalPut,
+		bucket: u._bucket,
+		key:    key,
+		value:  value,
+	})
+}
+
+func (u *memoryUpdate) appendLog(key, value []byte) {
+	u._entries = append(u._entries, journalEntry{
+		op:     journalAppend,
+		bucket: u._bucket,
+		key:    key,
+		value:  value,
+	})
+}
+
+// Get all logs of the key, including pending ones.
+func (u *memoryUpdate) logs(key []byte) [][]byte {
+	ls := slices.Clip(u._kv._logs[string(u._bucket)][string(key)])
+	for _, e := range u._entries {
+		if e.op == journalAppend && bytes.Equal(e.key, key) {
+			ls = append(ls, e.value)
+		}
+	}
+	return ls
+}
+
+func (u *memoryUpdate) snapshot(key []byte) memorySnapshot {
+	if snapshot, ok := u._snapshots[string(key)]; ok {
+		return snapshot
+	}
+	return u._kv._snapshots[string(u._bucket)][string(key)]
+}
+
+func (u *memoryUpdate) putSnapshot(key []byte, snapshot memorySnapshot) {
+	u._snapshots[string(key)] = snapshot
+}
+
+func (kv *memoryKV) apply(e journalEntry) {
+	var (
+		bucket = string(e.bucket)
+		key    = string(e.key)
+	)
+	switch e.op {
+	case journalPut:
+		b, ok := kv._buckets[bucket]
+		if !ok {
+			b = map[string][]byte{}
+			kv._buckets[bucket] = b
+		}
+		b[key] = e.value
+	case journalAppend:
+		b, ok := kv._logs[bucket]
+		if !ok {
+			b = map[string][][]byte{}
+			kv._logs[bucket] = b
+		}
+		b[key] = append(b[key], e.value)
+	}
+}
+
+// Iterate keys in the bucket in key order, like bbolt.
+func (kv *memoryKV) keys(bucket []byte) []string {
+	kv._mu.RLock()
+	defer kv._mu.RUnlock()
+
+	b := kv._buckets[string(bucket)]
+	keys := make([]string, 0, len(b))
+	for k := range b {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func newMemoryInfoStore(kv *memoryKV) (*InfoStore, error) {
+	var server logsv1.ServerRecord
+	data := kv.get(bucketInfo, infoServerKey)
+	if data != nil {
+		if err := proto.Unmarshal(data, &server); err != nil {
+			return nil, err
+		}
+	}
+	return &InfoStore{
+		_server: &server,
+		_put: func(data []byte) error {
+			return kv.update(bucketInfo, func(u *memoryUpdate) error {
+				u.put(infoServerKey, data)
+				return nil
+			})
+		},
+	}, nil
+}
+
+// The record without logs is stored as the value of the key, and each log is appended to the key.
+// Like the bolt store, the snapshot is taken periodically, so that reading the current state does not replay all logs.
+type memoryRecordStore[T logsv1.InitRecord | logsv1.AcquisitionRecord, P ptr[T]] struct {
+	_bucketName []byte
+	_kv         *memoryKV
+}
+
+func newMemoryRecordStore[T logsv1.InitRecord | logsv1.AcquisitionRecord, P ptr[T]](kv *memoryKV) (ResourceRecordStore[T], error) {
+	s := &memoryRecordStore[T, P]{
+		_bucketName: bucketName[T, P](),
+		_kv:         kv,
+	}
+	// Logs replayed from the journal have no snapshot yet.
+	if err := s.takeSnapshots(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *memoryRecordStore[T, P]) Get(identifier string) (*T, error) {
+	data, ls := s._kv.getWithLogs(s._bucketName, []byte(identifier))
+	if data == nil {
+		return nil, ErrRecordNotFound
+	}
+	var r P = new(T)
+	if err := proto.Unmarshal(data, r); err != nil {
+		return nil, err
+	}
+	logs := recordLogs(r)
+	for _, data := range ls {
+		l := logs.NewElement()
+		if err := proto.Unmarshal(data, l.Message().Interface()); err != nil {
+			return nil, err
+		}
+		logs.Append(l)
+	}
+	return r, nil
+}
+
+func (s *memoryRecordStore[T, P]) Put(identifiers []string, fn func(identifier string, r *T, update bool)) error {
+	return s._kv.update(s._bucketName, func(u *memoryUpdate) error {
+		for _, identifier := range identifiers {
+			var (
+				key      = []byte(identifier)
+				r      P = new(T)
+				update bool
+			)
+			// Only the record without logs is read.
+			data := u.get(key)
+			if data != nil {
+				update = true
+				if err := proto.Unmarshal(data, r); err != nil {
+					return err
+				}
+			}
+
+			fn(identifier, r, update)
+			ls := recordLogs(r)
+			for i := 0; i < ls.Len(); i++ {
+				data, err := proto.Marshal(ls.Get(i).Message().Interface())
+				if err != nil {
+					return err
+				}
+				u.appendLog(key, data)
+			}
+			clearLogs(r)
+			data, err := proto.Marshal(r)
+			if err != nil {
+				return err
+			}
+			u.put(key, data)
+			if err := s.takeSnapshot(u, key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Take snapshot when the number of logs after the last snapshot reaches the interval.
+func (s *memoryRecordStore[T, P]) takeSnapshot(u *memoryUpdate, key []byte) error {
+	var (
+		snapshot = u.snapshot(key)
+		ls       = u.logs(key)
+	)
+	if len(ls)-snapshot.n < snapshotInterval {
+		return nil
+	}
+	r, err := s.compact(u.get(key), snapshot, ls[snapshot.n:])
+	if err != nil {
+		return err
+	}
+	data, err := proto.Marshal(P(r))
+	if err != nil {
+		return err
+	}
+	u.putSnapshot(key, memorySnapshot{
+		data: data,
+		n:    len(ls),
+	})
+	return nil
+}
+
+// Take snapshots of all records, e.g. replayed from the journal.
+func (s *memoryRecordStore[T, P]) takeSnapshots() error {
+	keys := s._kv.keys(s._bucketName)
+	return s._kv.update(s._bucketName, func(u *memoryUpdate) error {
+		for _, key := range keys {
+			if err := s.takeSnapshot(u, []byte(key)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Compact the snapshot and the logs after it, into the latest record having only logs required to restore the current state.
+func (s *memoryRecordStore[T, P]) compact(data []byte, snapshot memorySnapshot, tail [][]byte) (*T, error) {
+	var r P = new(T)
+	if err := proto.Unmarshal(data, r); err != nil {
+		return nil, err
+	}
+	logs := recordLogs(r)
+	if snapshot.data != nil {
+		var last P = new(T)
+		if err := proto.Unmarshal(snapshot.data, last); err != nil {
+			return nil, err
+		}
+		ls := recordLogs(last)
+		for i := 0; i < ls.Len(); i++ {
+			logs.Append(ls.Get(i))
+		}
+	}
+	for _, data := range tail {
+		l := logs.NewElement()
+		if err := proto.Unmarshal(data, l.Message().Interface()); err != nil {
+			return nil, err
+		}
+		logs.Append(l)
+	}
+	compact(r)
+	return r, nil
+}
+
+func (s *memoryRecordStore[T, P]) ForEach(fn func(identifier string, record *T) error) error {
+	for _, identifier := range s._kv.keys(s._bucketName) {
+		r, err := s.Get(identifier)
+		if err != nil {
+			return err
+		}
+		if err := fn(identifier, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *memoryRecordStore[T, P]) GetSnapshot(identifier string) (*T, error) {
+	data, snapshot, tail := s._kv.getWithSnapshot(s._bucketName, []byte(identifier))
+	if data == nil {
+		return nil, ErrRecordNotFound
+	}
+	return s.compact(data, snapshot, tail)
+}
+
+func (s *memoryRecordStore[T, P]) ForEachSnapshot(fn func(identifier string, record *T) error) error {
+	for _, identifier := range s._kv.keys(s._bucketName) {
+		r, err := s.GetSnapshot(identifier)
+		if err != nil {
+			return err
+		}
+		if err := fn(identifier, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newMemoryBackend(kv *memoryKV, close func() error) (Backend, error) {
+	info, err := newMemoryInfoStore(kv)
+	if err != nil {
+		return nil, err
+	}
+	init, err := newMemoryRecordStore[logsv1.InitRecord](kv)
+	if err != nil {
+		return nil, err
+	}
+	acquire, err := newMemoryRecordStore[logsv1.AcquisitionRecord](kv)
+	if err != nil {
+		return nil, err
+	}
+	return &backend{
+		_info:    info,
+		_init:    init,
+		_acquire: acquire,
+		_close:   close,
+	}, nil
+}
+
+var (
+	memoryMu       sync.Mutex
+	memoryStorages = map[string]*memoryStorage{}
+)
+
+type memoryStorage struct {
+	sem  chan struct{}
+	kv   *memoryKV
+	refs int // Number of Backend opened or waiting.
+}
+
+// Discard the state when no Backend is opened or waiting for it.
+func (s *memoryStorage) unref(name string) {
+	memoryMu.Lock()
+	defer memoryMu.Unlock()
+
+	s.refs--
+	if s.refs == 0 {
+		delete(memoryStorages, name)
+	}
+}
+
+// OpenMemoryBackend opens Backend on memory, identified by the name.
+// While Backend with the same name is opened, succeeding Backend waiting for it takes over the state on Close.
+// The state is discarded when the last Backend is closed.
+// It blocks while other Backend with the same name is opened in the process, until ctx is done.
+//
+// Because the state is not shared between processes, this Backend is only suitable for single process execution and testing.
+func OpenMemoryBackend(ctx context.Context, name string) (Backend, error) {
+	memoryMu.Lock()
+	s, ok := memoryStorages[name]
+	if !ok {
+		s = &memoryStorage{
+			sem: make(chan struct{}, 1),
+			kv:  newMemoryKV(),
+		}
+		memoryStorages[name] = s
+	}
+	s.refs++
+	memoryMu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		s.unref(name)
+		return nil, ctx.Err()
+	case s.sem <- struct{}{}:
+	}
+	b, err := newMemoryBackend(s.kv, sync.OnceValue(func() error {
+		<-s.sem
+		s.unref(name)
+		return nil
+	}))
+	if err != nil {
+		<-s.sem
+		s.unref(name)
+		return nil, err
+	}
+	return b, nil
+}
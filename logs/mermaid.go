@@ -0,0 +1,130 @@
+package logs
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type mermaidParticipants struct {
+	prefix string
+	ids    map[string]string
+	keys   []string // In the order of appearance.
+	names  map[string]string
+}
+
+// Get the participant ID of the key, which is displayed as name.
+func (p *mermaidParticipants) id(key, name string) string {
+	if p.ids == nil {
+		p.ids = map[string]string{}
+		p.names = map[string]string{}
+	}
+	id, ok := p.ids[key]
+	if !ok {
+		id = fmt.Sprintf("%s%d", p.prefix, len(p.keys)+1)
+		p.ids[key] = id
+		p.keys = append(p.keys, key)
+		p.names[key] = name
+	}
+	return id
+}
+
+func (p *mermaidParticipants) declare(w io.Writer) {
+	for _, key := range p.keys {
+		_, _ = fmt.Fprintf(w, "    participant %s as %s\n", p.ids[key], mermaidText(p.names[key]))
+	}
+}
+
+// Characters breaking the syntax of the diagram.
+var mermaidReplacer = strings.NewReplacer(";", ",", "#", "", "\n", " ", "->", " → ")
+
+func mermaidText(s string) string {
+	return mermaidReplacer.Replace(s)
+}
+
+// Write events as a Mermaid sequence diagram.
+// Participants are callers in the order of appearance, the server, and resources.
+func writeMermaid(w io.Writer, events []Event) error {
+	var (
+		callers   = mermaidParticipants{prefix: "c"}
+		resources = mermaidParticipants{prefix: "r"}
+		active    = map[string]bool{}
+		lines     []string
+	)
+	message := func(from, arrow, to, text string) {
+		lines = append(lines, fmt.Sprintf("    %s%s%s: %s", from, arrow, to, mermaidText(text)))
+	}
+	note := func(over, text string) {
+		lines = append(lines, fmt.Sprintf("    Note over %s: %s", over, mermaidText(text)))
+	}
+	// Caller waiting for the acquisition is activated.
+	activate := func(c string, activated bool) {
+		if active[c] == activated {
+			return
+		}
+		active[c] = activated
+		if activated {
+			lines = append(lines, "    activate "+c)
+		} else {
+			lines = append(lines, "    deactivate "+c)
+		}
+	}
+
+	for i := range events {
+		e := &events[i]
+		if e.Resource == "" {
+			if e.Operation == "server:launched" {
+				note("server", "launched "+e.Addr)
+			} else {
+				note("server", strings.TrimPrefix(e.Operation, "server:"))
+			}
+			continue
+		}
+
+		r := resources.id(e.Resource, e.Resource)
+		if e.Admin {
+			// Admin operations are performed through the server, by rsmapctl.
+			message("server", "->>", r, e.Operation+" (admin)")
+			continue
+		}
+		c := callers.id(e.OperatorID, e.Context.String())
+		switch e.Operation {
+		case "init:started":
+			message(c, "->>", r, "init")
+		case "init:completed":
+			message(r, "-->>", c, "init completed")
+		case "init:failed":
+			message(r, "--x", c, "init failed")
+		case "init:reset":
+			note(r, "init reset")
+		case "acquiring":
+			message(c, "->>", r, "acquire")
+			activate(c, true)
+		case "acquired":
+			text := fmt.Sprintf("acquired +%d (%d/%d)", e.N, e.Total, e.Max)
+			if e.Wait > 0 {
+				text += fmt.Sprintf(", waited %s", e.Wait)
+			}
+			message(r, "-->>", c, text)
+			activate(c, false)
+		case "canceled":
+			message(r, "--x", c, fmt.Sprintf("canceled, waited %s", e.Wait))
+			activate(c, false)
+		case "released":
+			message(c, "->>", r, fmt.Sprintf("release -%d (%d/%d)", e.N, e.Total, e.Max))
+		default:
+			message(c, "->>", r, e.Operation)
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	_, _ = fmt.Fprintln(bw, "sequenceDiagram")
+	callers.declare(bw)
+	_, _ = fmt.Fprintln(bw, "    participant server")
+	resources.declare(bw)
+	for _, l := range lines {
+		_, _ = fmt.Fprintln(bw, l)
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,185 @@
+package logs
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+	"time"
+)
+
+//go:embed report.html
+var reportHTML string
+
+var reportTemplate = template.Must(template.New("report").Parse(reportHTML))
+
+type (
+	// Data embedded in the HTML report.
+	reportData struct {
+		Start     time.Time        `json:"start"`
+		Duration  int64            `json:"duration"` // Nanoseconds.
+		Resources []reportResource `json:"resources"`
+	}
+
+	reportResource struct {
+		Name string      `json:"name"`
+		Max  int64       `json:"max"`
+		Rows []reportRow `json:"rows"`
+	}
+
+	// Row of the chart. Init operations are on the first row, and each operator has its own row.
+	reportRow struct {
+		Label   string           `json:"label"`
+		Callers []exportedCaller `json:"callers"`
+		Spans   []reportSpan     `json:"spans"`
+	}
+
+	reportSpan struct {
+		// One of "init", "init:failed", "waiting" and "holding".
+		Kind string `json:"kind"`
+		// Offset from the start of the report, in nanoseconds.
+		Start    int64            `json:"start"`
+		End      int64            `json:"end"`
+		Finished bool             `json:"finished"`
+		N        int64            `json:"n"`
+		Callers  []exportedCaller `json:"callers"`
+	}
+
+	// State of the resource while building the report.
+	reportBuilder struct {
+		resource  *reportResource
+		rows      map[string]int
+		initStart *Event
+		waiting   map[string]*Event
+		holding   map[string]*Event
+	}
+)
+
+// Write events as a self-contained HTML file, which shows the timeline of holders and waiters of each resource.
+func writeHTMLReport(w io.Writer, events []Event) error {
+	data := reportData{
+		Resources: []reportResource{},
+	}
+	if len(events) > 0 {
+		data.Start = events[0].Timestamp
+		data.Duration = int64(events[len(events)-1].Timestamp.Sub(data.Start))
+	}
+	offset := func(e *Event) int64 {
+		return int64(e.Timestamp.Sub(data.Start))
+	}
+
+	var (
+		builders = map[string]*reportBuilder{}
+		order    []string
+	)
+	builder := func(resource string) *reportBuilder {
+		b, ok := builders[resource]
+		if !ok {
+			b = &reportBuilder{
+				resource: &reportResource{
+					Name: resource,
+					Rows: []reportRow{{Label: "init"}},
+				},
+				rows:    map[string]int{},
+				waiting: map[string]*Event{},
+				holding: map[string]*Event{},
+			}
+			builders[resource] = b
+			order = append(order, resource)
+		}
+		return b
+	}
+	span := func(b *reportBuilder, row int, kind string, start, end *Event) {
+		s := reportSpan{
+			Kind:     kind,
+			Start:    offset(start),
+			End:      data.Duration,
+			Finished: end != nil,
+			N:        start.N,
+			Callers:  newExportedEvent(*start).Context,
+		}
+		if end != nil {
+			s.End = offset(end)
+		}
+		b.resource.Rows[row].Spans = append(b.resource.Rows[row].Spans, s)
+	}
+	row := func(b *reportBuilder, e *Event) int {
+		operator := e.OperatorID
+		i, ok := b.rows[operator]
+		if !ok {
+			i = len(b.resource.Rows)
+			b.rows[operator] = i
+			b.resource.Rows = append(b.resource.Rows, reportRow{
+				Label:   e.Context.String(),
+				Callers: newExportedEvent(*e).Context,
+			})
+		}
+		return i
+	}
+
+	for i := range events {
+		e := &events[i]
+		if e.Resource == "" {
+			continue
+		}
+		b := builder(e.Resource)
+		b.resource.Max = max(b.resource.Max, e.Max)
+		operator := e.OperatorID
+		switch e.Operation {
+		case "init:started":
+			b.initStart = e
+		case "init:completed", "init:failed":
+			if b.initStart != nil {
+				kind := "init"
+				if e.Operation == "init:failed" {
+					kind = "init:failed"
+				}
+				span(b, 0, kind, b.initStart, e)
+				b.initStart = nil
+			}
+		case "acquiring":
+			row(b, e)
+			if _, ok := b.waiting[operator]; !ok {
+				b.waiting[operator] = e
+			}
+		case "acquired":
+			r := row(b, e)
+			if start, ok := b.waiting[operator]; ok {
+				span(b, r, "waiting", start, e)
+				delete(b.waiting, operator)
+			}
+			b.holding[operator] = e
+		case "canceled":
+			if start, ok := b.waiting[operator]; ok {
+				span(b, row(b, start), "waiting", start, e)
+				delete(b.waiting, operator)
+			}
+		case "released":
+			if start, ok := b.holding[operator]; ok {
+				span(b, row(b, start), "holding", start, e)
+				delete(b.holding, operator)
+			}
+		}
+	}
+
+	for _, resource := range order {
+		b := builders[resource]
+		// Unfinished operations last until the end of the report.
+		if b.initStart != nil {
+			span(b, 0, "init", b.initStart, nil)
+		}
+		for _, operator := range sortedKeys(b.waiting) {
+			start := b.waiting[operator]
+			span(b, row(b, start), "waiting", start, nil)
+		}
+		for _, operator := range sortedKeys(b.holding) {
+			start := b.holding[operator]
+			span(b, row(b, start), "holding", start, nil)
+		}
+		if len(b.resource.Rows[0].Spans) == 0 {
+			b.resource.Rows = b.resource.Rows[1:]
+		}
+		data.Resources = append(data.Resources, *b.resource)
+	}
+
+	return reportTemplate.Execute(w, data)
+}
@@ -0,0 +1,106 @@
+package logs
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"go.etcd.io/bbolt"
+
+	logsv1 "github.com/daichitakahashi/rsmap/internal/proto/logs/v1"
+)
+
+// SchemaVersion is the version of the layout of buckets and records in logs.db, stored in the info bucket.
+//
+//   - 1: Each record of the resource is stored as a single value. Database without the version is regarded as this.
+//   - 2: Each resource has its own nested bucket, that contains the record, logs and the snapshot.
+const SchemaVersion = 2
+
+var infoVersionKey = []byte("version")
+
+// ErrIncompatibleSchema is returned when logs.db is written by the newer version of rsmap.
+var ErrIncompatibleSchema = errors.New("incompatible schema version of logs.db")
+
+// Migrations of the database, where migrations[i] converts the version i+1 into i+2.
+// Each migration runs in the same transaction as the update of the version.
+var migrations = []func(tx *bbolt.Tx) error{
+	// Convert records stored as a single value into nested buckets.
+	func(tx *bbolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(bucketInit)
+		if err != nil {
+			return err
+		}
+		if err := migrateRecords[logsv1.InitRecord](b); err != nil {
+			return err
+		}
+		b, err = tx.CreateBucketIfNotExists(bucketAcquire)
+		if err != nil {
+			return err
+		}
+		return migrateRecords[logsv1.AcquisitionRecord](b)
+	},
+}
+
+// Get the schema version of the database.
+func schemaVersion(tx *bbolt.Tx) (int, error) {
+	b := tx.Bucket(bucketInfo)
+	if b == nil {
+		return 1, nil
+	}
+	data := b.Get(infoVersionKey)
+	if data == nil {
+		return 1, nil
+	}
+	version, err := strconv.Atoi(string(data))
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("%w: invalid version %q", ErrIncompatibleSchema, data)
+	}
+	return version, nil
+}
+
+func checkSchemaVersion(version int) error {
+	if version > SchemaVersion {
+		return fmt.Errorf("%w: version %d is written by the newer rsmap, which supports up to version %d; upgrade rsmap to use it",
+			ErrIncompatibleSchema, version, SchemaVersion)
+	}
+	return nil
+}
+
+// Migrate the database to the current schema version, or refuse the database of the newer version.
+func migrate(tx *bbolt.Tx) error {
+	version, err := schemaVersion(tx)
+	if err != nil {
+		return err
+	}
+	if err := checkSchemaVersion(version); err != nil {
+		return err
+	}
+	if version == SchemaVersion {
+		return nil
+	}
+
+	for _, m := range migrations[version-1:] {
+		if err := m(tx); err != nil {
+			return fmt.Errorf("failed to migrate logs.db from version %d: %w", version, err)
+		}
+	}
+	b, err := tx.CreateBucketIfNotExists(bucketInfo)
+	if err != nil {
+		return err
+	}
+	return b.Put(infoVersionKey, []byte(strconv.Itoa(SchemaVersion)))
+}
+
+// CheckSchema reports whether the database can be read by this version, without migration.
+// Older databases are accepted, since they are migrated on open.
+func CheckSchema(db *bbolt.DB) error {
+	return db.View(checkSchema)
+}
+
+func checkSchema(tx *bbolt.Tx) error {
+	version, err := schemaVersion(tx)
+	if err != nil {
+		return err
+	}
+	return checkSchemaVersion(version)
+}
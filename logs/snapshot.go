@@ -0,0 +1,71 @@
+package logs
+
+import (
+	"slices"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	logsv1 "github.com/daichitakahashi/rsmap/internal/proto/logs/v1"
+)
+
+// Get mutable logs of the record.
+func recordLogs(r protoreflect.ProtoMessage) protoreflect.List {
+	m := r.ProtoReflect()
+	return m.Mutable(m.Descriptor().Fields().ByName("logs")).List()
+}
+
+func clearLogs(r protoreflect.ProtoMessage) {
+	m := r.ProtoReflect()
+	m.Clear(m.Descriptor().Fields().ByName("logs"))
+}
+
+// Drop logs not required to restore the current state of the resource.
+func compact(r protoreflect.ProtoMessage) {
+	switch r := r.(type) {
+	case *logsv1.InitRecord:
+		// Only the last try matters.
+		if len(r.Logs) > 1 {
+			r.Logs = r.Logs[len(r.Logs)-1:]
+		}
+	case *logsv1.AcquisitionRecord:
+		r.Logs = compactAcquisitionLogs(r.Logs)
+	}
+}
+
+// Keep "acquired" logs of current holders, and "acquiring" logs of operators still waiting, in the original order.
+func compactAcquisitionLogs(ls []*logsv1.AcquisitionLog) []*logsv1.AcquisitionLog {
+	// Index of the log representing current state of each operator.
+	latest := map[string]int{}
+	for i, l := range ls {
+		operator := OperatorID(l)
+		switch l.Event {
+		case logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING:
+			// The position in the queue is decided by the first "acquiring".
+			if j, ok := latest[operator]; ok && ls[j].Event == logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING {
+				continue
+			}
+			latest[operator] = i
+		case logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED:
+			latest[operator] = i
+		case logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED:
+			delete(latest, operator)
+		case logsv1.AcquisitionEvent_ACQUISITION_EVENT_CANCELED:
+			// No longer waiting.
+			if j, ok := latest[operator]; ok && ls[j].Event == logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING {
+				delete(latest, operator)
+			}
+		}
+	}
+
+	indices := make([]int, 0, len(latest))
+	for _, i := range latest {
+		indices = append(indices, i)
+	}
+	slices.Sort(indices)
+
+	compacted := make([]*logsv1.AcquisitionLog, 0, len(indices))
+	for _, i := range indices {
+		compacted = append(compacted, ls[i])
+	}
+	return compacted
+}
@@ -0,0 +1,158 @@
+package logs
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+type (
+	// ResourceStats is the statistics of contention on the resource.
+	ResourceStats struct {
+		Resource string
+		Max      int64
+		// Number of acquisitions by mode.
+		Shared, Exclusive int
+		// Time spent waiting for the acquisitions.
+		Wait      DurationStats
+		TotalWait time.Duration
+		// Time from the acquisitions to the releases. Locks not released are excluded.
+		Hold      DurationStats
+		TotalHold time.Duration
+		// Peak number of acquired slots.
+		PeakConcurrency int64
+		// Duration of the last try of the initialization.
+		InitDuration time.Duration
+		// Number of the initialization tries except the first one.
+		InitRetries int
+		// Last init operation, such as "init:completed". Empty if the resource is not initialized.
+		InitResult string
+		// Callers grouped by the location, sorted by total wait time in descending order.
+		Callers []CallerStats
+	}
+
+	DurationStats struct {
+		P50, P95, Max time.Duration
+	}
+
+	CallerStats struct {
+		Context      CallerContext
+		Acquisitions int
+		TotalWait    time.Duration
+	}
+)
+
+// Stats aggregates events read by [ReadEvents] for each resource, in the order of resource name.
+func Stats(events []Event) []ResourceStats {
+	type state struct {
+		stats     ResourceStats
+		waits     []time.Duration
+		holds     []time.Duration
+		initStart time.Time
+		inits     int
+		acquired  map[string]time.Time
+		callers   map[string]*CallerStats // Keyed by the caller location.
+	}
+	states := map[string]*state{}
+
+	for _, e := range events {
+		if e.Resource == "" {
+			continue
+		}
+		s, ok := states[e.Resource]
+		if !ok {
+			s = &state{
+				stats: ResourceStats{
+					Resource: e.Resource,
+				},
+				acquired: map[string]time.Time{},
+				callers:  map[string]*CallerStats{},
+			}
+			states[e.Resource] = s
+		}
+		s.stats.Max = max(s.stats.Max, e.Max)
+		operator := e.OperatorID
+
+		switch e.Operation {
+		case "init:started":
+			s.initStart = e.Timestamp
+			s.inits++
+			s.stats.InitResult = e.Operation
+		case "init:reset":
+			s.stats.InitResult = e.Operation
+		case "init:completed", "init:failed":
+			s.stats.InitResult = e.Operation
+			if !s.initStart.IsZero() {
+				s.stats.InitDuration = e.Timestamp.Sub(s.initStart)
+				s.initStart = time.Time{}
+			}
+		case "acquired":
+			if e.Exclusive {
+				s.stats.Exclusive++
+			} else {
+				s.stats.Shared++
+			}
+			s.waits = append(s.waits, e.Wait)
+			s.stats.TotalWait += e.Wait
+			s.stats.PeakConcurrency = max(s.stats.PeakConcurrency, e.Total)
+			s.acquired[operator] = e.Timestamp
+
+			// Operators are created on every acquisition, so group them by the call site.
+			location := callerLocation(e.Context)
+			c, ok := s.callers[location]
+			if !ok {
+				c = &CallerStats{
+					Context: e.Context,
+				}
+				s.callers[location] = c
+			}
+			c.Acquisitions++
+			c.TotalWait += e.Wait
+		case "released":
+			if start, ok := s.acquired[operator]; ok {
+				s.holds = append(s.holds, e.Timestamp.Sub(start))
+				s.stats.TotalHold += e.Timestamp.Sub(start)
+				delete(s.acquired, operator)
+			}
+		}
+	}
+
+	stats := make([]ResourceStats, 0, len(states))
+	for _, resource := range sortedKeys(states) {
+		s := states[resource]
+		s.stats.Wait = newDurationStats(s.waits)
+		s.stats.Hold = newDurationStats(s.holds)
+		s.stats.InitRetries = max(s.inits-1, 0)
+
+		callers := make([]CallerStats, 0, len(s.callers))
+		for _, location := range sortedKeys(s.callers) {
+			callers = append(callers, *s.callers[location])
+		}
+		slices.SortStableFunc(callers, func(a, b CallerStats) int {
+			return cmp.Compare(b.TotalWait, a.TotalWait)
+		})
+		s.stats.Callers = callers
+
+		stats = append(stats, s.stats)
+	}
+	return stats
+}
+
+func newDurationStats(ds []time.Duration) DurationStats {
+	if len(ds) == 0 {
+		return DurationStats{}
+	}
+	sorted := slices.Clone(ds)
+	slices.Sort(sorted)
+	return DurationStats{
+		P50: percentile(sorted, 50),
+		P95: percentile(sorted, 95),
+		Max: sorted[len(sorted)-1],
+	}
+}
+
+// Get percentile of sorted values by the nearest-rank method.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (len(sorted)*p + 99) / 100 // Ceil.
+	return sorted[max(rank-1, 0)]
+}
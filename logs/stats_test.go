@@ -0,0 +1,119 @@
+package logs
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"gotest.tools/v3/assert"
+
+	logsv1 "github.com/daichitakahashi/rsmap/internal/proto/logs/v1"
+)
+
+func TestStats(t *testing.T) {
+	t.Parallel()
+
+	db := prepareEvents(t)
+	events, err := ReadEvents(db, Filter{})
+	assert.NilError(t, err)
+
+	stats := Stats(events)
+	assert.DeepEqual(t, stats, []ResourceStats{
+		{
+			Resource:  "treasure",
+			Max:       5,
+			Shared:    1,
+			Exclusive: 1,
+			Wait: DurationStats{
+				P50: 100,
+				P95: 200,
+				Max: 200,
+			},
+			TotalWait: 300,
+			// Lock of bob is not released yet.
+			Hold: DurationStats{
+				P50: 200,
+				P95: 200,
+				Max: 200,
+			},
+			TotalHold:       200,
+			PeakConcurrency: 5,
+			InitDuration:    100,
+			InitRetries:     0,
+			InitResult:      "init:completed",
+			Callers: []CallerStats{
+				{Context: events[7].Context, Acquisitions: 1, TotalWait: 200}, // bob
+				{Context: events[4].Context, Acquisitions: 1, TotalWait: 100}, // alice
+			},
+		},
+	}, ignoreProtoUnexported)
+}
+
+func TestStats_Mode(t *testing.T) {
+	t.Parallel()
+
+	var (
+		alice = []*logsv1.Caller{{File: "alice.go", Line: 10, Hash: "a1"}}
+		bob   = []*logsv1.Caller{{File: "bob.go", Line: 20, Hash: "b1"}}
+	)
+	// Shared lock of the resource with max parallelism 1 takes all slots, but it is not exclusive.
+	events := acquisitionEvents("treasure", &logsv1.AcquisitionRecord{
+		Max: 1,
+		Logs: []*logsv1.AcquisitionLog{
+			{Event: logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED, N: 1, Context: alice, Timestamp: 100, Exclusive: proto.Bool(false)},
+			{Event: logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED, Context: alice, Timestamp: 200},
+			{Event: logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED, N: 1, Context: bob, Timestamp: 300, Exclusive: proto.Bool(true)},
+			{Event: logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED, Context: bob, Timestamp: 400},
+			// Recorded by older version without the mode.
+			{Event: logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED, N: 1, Context: alice, Timestamp: 500},
+		},
+	})
+	stats := Stats(events)
+	assert.Equal(t, len(stats), 1)
+	assert.Equal(t, stats[0].Shared, 1)
+	assert.Equal(t, stats[0].Exclusive, 2)
+}
+
+func TestStats_Callers(t *testing.T) {
+	t.Parallel()
+
+	var (
+		// Each acquisition has its own operator, even if it is called from the same location.
+		alice1 = []*logsv1.Caller{{File: "alice.go", Line: 10, Hash: "a1"}}
+		alice2 = []*logsv1.Caller{{File: "alice.go", Line: 10, Hash: "a2"}}
+		bob    = []*logsv1.Caller{{File: "bob.go", Line: 20, Hash: "b1"}}
+	)
+	events := acquisitionEvents("treasure", &logsv1.AcquisitionRecord{
+		Max: 1,
+		Logs: []*logsv1.AcquisitionLog{
+			{Event: logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING, Context: alice1, Timestamp: 100},
+			{Event: logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED, N: 1, Context: alice1, Timestamp: 110},
+			{Event: logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING, Context: bob, Timestamp: 120},
+			{Event: logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING, Context: alice2, Timestamp: 130},
+			{Event: logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED, Context: alice1, Timestamp: 200},
+			{Event: logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED, N: 1, Context: bob, Timestamp: 220},
+			{Event: logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED, Context: bob, Timestamp: 300},
+			{Event: logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED, N: 1, Context: alice2, Timestamp: 330},
+		},
+	})
+
+	// Acquisitions from the same location are grouped.
+	stats := Stats(events)
+	assert.Equal(t, len(stats), 1)
+	assert.DeepEqual(t, stats[0].Callers, []CallerStats{
+		{Context: alice1, Acquisitions: 2, TotalWait: 210},
+		{Context: bob, Acquisitions: 1, TotalWait: 100},
+	}, ignoreProtoUnexported)
+}
+
+func TestPercentile(t *testing.T) {
+	t.Parallel()
+
+	var ds []time.Duration
+	for i := 1; i <= 20; i++ {
+		ds = append(ds, time.Duration(i))
+	}
+	assert.DeepEqual(t, newDurationStats(ds), DurationStats{P50: 10, P95: 19, Max: 20})
+	assert.DeepEqual(t, newDurationStats([]time.Duration{3}), DurationStats{P50: 3, P95: 3, Max: 3})
+	assert.DeepEqual(t, newDurationStats(nil), DurationStats{})
+}
@@ -0,0 +1,56 @@
+package logs
+
+import (
+	"time"
+
+	"go.etcd.io/bbolt"
+
+	logsv1 "github.com/daichitakahashi/rsmap/internal/proto/logs/v1"
+)
+
+// Summary is the summary of an execution recorded in logs.db.
+type Summary struct {
+	// Time of the first launch of the server.
+	Start time.Time
+	// Time of the last stop of the server. Zero if the last server is not stopped.
+	End time.Time
+	// Number of server launches, including the ones by failover.
+	Servers int
+	// Names of the resources in the order of name.
+	Resources []string
+}
+
+// Summarize reads the summary of the execution from logs.db.
+// Like [ReadEvents], the database is only read, and older schema versions are read without migration.
+func Summarize(db *bbolt.DB) (*Summary, error) {
+	var s Summary
+	err := db.View(func(tx *bbolt.Tx) error {
+		if err := checkSchema(tx); err != nil {
+			return err
+		}
+
+		server, err := viewServerRecord(tx)
+		if err != nil {
+			return err
+		}
+		for _, l := range server.Logs {
+			switch l.Event {
+			case logsv1.ServerEvent_SERVER_EVENT_LAUNCHED:
+				if s.Servers == 0 {
+					s.Start = time.Unix(0, l.Timestamp)
+				}
+				s.Servers++
+				s.End = time.Time{}
+			case logsv1.ServerEvent_SERVER_EVENT_STOPPED:
+				s.End = time.Unix(0, l.Timestamp)
+			}
+		}
+
+		s.Resources, err = resourceNames(tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
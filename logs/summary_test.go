@@ -0,0 +1,39 @@
+package logs
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	logsv1 "github.com/daichitakahashi/rsmap/internal/proto/logs/v1"
+)
+
+func TestSummarize(t *testing.T) {
+	t.Parallel()
+
+	db := prepareEvents(t)
+
+	s, err := Summarize(db)
+	assert.NilError(t, err)
+	assert.Assert(t, s.Start.Equal(time.Unix(0, 100)))
+	assert.Assert(t, s.End.IsZero()) // Not stopped yet.
+	assert.Equal(t, s.Servers, 1)
+	assert.DeepEqual(t, s.Resources, []string{"treasure"})
+
+	// Stop, and launch by failover.
+	info, err := NewInfoStore(db)
+	assert.NilError(t, err)
+	for _, l := range []*logsv1.ServerLog{
+		{Event: logsv1.ServerEvent_SERVER_EVENT_STOPPED, Timestamp: 900},
+		{Event: logsv1.ServerEvent_SERVER_EVENT_LAUNCHED, Timestamp: 1000},
+		{Event: logsv1.ServerEvent_SERVER_EVENT_STOPPED, Timestamp: 1100},
+	} {
+		assert.NilError(t, info.PutServerLog(l))
+	}
+	s, err = Summarize(db)
+	assert.NilError(t, err)
+	assert.Assert(t, s.Start.Equal(time.Unix(0, 100)))
+	assert.Assert(t, s.End.Equal(time.Unix(0, 1100)))
+	assert.Equal(t, s.Servers, 2)
+}
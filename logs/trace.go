@@ -0,0 +1,223 @@
+package logs
+
+import (
+	"encoding/json"
+	"io"
+	"slices"
+	"time"
+)
+
+type (
+	// Chrome Trace Event format.
+	// See: https://docs.google.com/document/d/1CvAClvFfyA5R-PhYUmn5OOQtYMH4h6I0nSsKchNAySU
+	traceFile struct {
+		TraceEvents     []traceEvent `json:"traceEvents"`
+		DisplayTimeUnit string       `json:"displayTimeUnit"`
+	}
+
+	traceEvent struct {
+		Name  string         `json:"name"`
+		Cat   string         `json:"cat,omitempty"`
+		Phase string         `json:"ph"`
+		TS    float64        `json:"ts"` // Microseconds.
+		Dur   *float64       `json:"dur,omitempty"`
+		PID   int            `json:"pid"`
+		TID   int            `json:"tid"`
+		Scope string         `json:"s,omitempty"`
+		Args  map[string]any `json:"args,omitempty"`
+	}
+
+	// Process of the trace, that represents a resource.
+	// Each operator has its own thread, and init operations are on the thread 0.
+	traceProcess struct {
+		pid     int
+		threads map[string]int
+
+		initStart *Event
+		waiting   map[string]*Event
+		holding   map[string]*Event
+	}
+)
+
+const (
+	tracePIDServer = 0
+	traceTIDInit   = 0
+)
+
+func traceTimestamp(t time.Time) float64 {
+	return float64(t.UnixNano()) / float64(time.Microsecond)
+}
+
+// Write events as Chrome Trace Event JSON, which can be loaded into Perfetto or chrome://tracing.
+// Each resource is a process, and each acquisition appears as slices of "waiting" and "holding" on the thread of the operator.
+func writeChromeTrace(w io.Writer, events []Event) error {
+	var (
+		traceEvents []traceEvent
+		processes   = map[string]*traceProcess{}
+		order       []*traceProcess
+	)
+	meta := func(name string, pid, tid int, value string) {
+		traceEvents = append(traceEvents, traceEvent{
+			Name:  name,
+			Phase: "M",
+			PID:   pid,
+			TID:   tid,
+			Args:  map[string]any{"name": value},
+		})
+	}
+	slice := func(name, cat string, pid, tid int, start, end *Event, args map[string]any) {
+		e := traceEvent{
+			Name:  name,
+			Cat:   cat,
+			Phase: "B", // Not finished.
+			TS:    traceTimestamp(start.Timestamp),
+			PID:   pid,
+			TID:   tid,
+			Args:  args,
+		}
+		if end != nil {
+			dur := traceTimestamp(end.Timestamp) - e.TS
+			e.Phase = "X"
+			e.Dur = &dur
+		}
+		traceEvents = append(traceEvents, e)
+	}
+	process := func(resource string) *traceProcess {
+		p, ok := processes[resource]
+		if !ok {
+			p = &traceProcess{
+				pid:     len(processes) + 1,
+				threads: map[string]int{},
+				waiting: map[string]*Event{},
+				holding: map[string]*Event{},
+			}
+			processes[resource] = p
+			order = append(order, p)
+			meta("process_name", p.pid, 0, resource)
+			meta("thread_name", p.pid, traceTIDInit, "init")
+		}
+		return p
+	}
+	thread := func(p *traceProcess, e *Event) int {
+		operator := e.OperatorID
+		tid, ok := p.threads[operator]
+		if !ok {
+			tid = len(p.threads) + 1
+			p.threads[operator] = tid
+			meta("thread_name", p.pid, tid, e.Context.String())
+		}
+		return tid
+	}
+
+	meta("process_name", tracePIDServer, 0, "server")
+	for i := range events {
+		e := &events[i]
+		if e.Resource == "" {
+			traceEvents = append(traceEvents, traceEvent{
+				Name:  e.Operation,
+				Cat:   "server",
+				Phase: "i",
+				TS:    traceTimestamp(e.Timestamp),
+				PID:   tracePIDServer,
+				Scope: "g", // Global marker.
+				Args: map[string]any{
+					"addr":    e.Addr,
+					"context": e.Context.String(),
+				},
+			})
+			continue
+		}
+
+		p := process(e.Resource)
+		operator := e.OperatorID
+		switch e.Operation {
+		case "init:started":
+			p.initStart = e
+		case "init:completed", "init:failed":
+			if p.initStart != nil {
+				slice("init", "init", p.pid, traceTIDInit, p.initStart, e, map[string]any{
+					"result":  e.Operation,
+					"context": operator,
+				})
+				p.initStart = nil
+			}
+		case "init:reset":
+			traceEvents = append(traceEvents, traceEvent{
+				Name:  e.Operation,
+				Cat:   "init",
+				Phase: "i",
+				TS:    traceTimestamp(e.Timestamp),
+				PID:   p.pid,
+				TID:   traceTIDInit,
+				Scope: "t",
+			})
+		case "acquiring":
+			if _, ok := p.waiting[operator]; !ok {
+				p.waiting[operator] = e
+			}
+		case "acquired":
+			tid := thread(p, e)
+			if start, ok := p.waiting[operator]; ok {
+				slice("waiting", "acquire", p.pid, tid, start, e, map[string]any{
+					"context": operator,
+				})
+				delete(p.waiting, operator)
+			}
+			p.holding[operator] = e
+		case "canceled":
+			if start, ok := p.waiting[operator]; ok {
+				slice("waiting", "acquire", p.pid, thread(p, start), start, e, map[string]any{
+					"context":  operator,
+					"canceled": true,
+				})
+				delete(p.waiting, operator)
+			}
+		case "released":
+			start, ok := p.holding[operator]
+			if !ok {
+				continue
+			}
+			slice("holding", "acquire", p.pid, thread(p, start), start, e, map[string]any{
+				"n":       start.N,
+				"total":   start.Total,
+				"max":     start.Max,
+				"admin":   e.Admin,
+				"context": operator,
+			})
+			delete(p.holding, operator)
+		}
+	}
+
+	// Unfinished operations.
+	for _, p := range order {
+		if p.initStart != nil {
+			slice("init", "init", p.pid, traceTIDInit, p.initStart, nil, nil)
+		}
+		for _, operator := range sortedKeys(p.waiting) {
+			start := p.waiting[operator]
+			slice("waiting", "acquire", p.pid, thread(p, start), start, nil, nil)
+		}
+		for _, operator := range sortedKeys(p.holding) {
+			start := p.holding[operator]
+			slice("holding", "acquire", p.pid, thread(p, start), start, nil, map[string]any{
+				"n":     start.N,
+				"total": start.Total,
+				"max":   start.Max,
+			})
+		}
+	}
+
+	return json.NewEncoder(w).Encode(traceFile{
+		TraceEvents:     traceEvents,
+		DisplayTimeUnit: "ms",
+	})
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,166 @@
+package rsmap
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const metricsPath = "/metrics"
+
+// Metrics of the server, exposed in Prometheus text format.
+// All methods are safe to call on nil.
+type metrics struct {
+	_reg       *prometheus.Registry
+	_holders   *prometheus.GaugeVec
+	_waiters   *prometheus.GaugeVec
+	_wait      *prometheus.HistogramVec
+	_hold      *prometheus.HistogramVec
+	_init      *prometheus.HistogramVec
+	_failovers prometheus.Counter
+
+	_mu        sync.Mutex
+	_acquired  map[string]time.Time
+	_initiated map[string]time.Time
+}
+
+var durationBuckets = prometheus.ExponentialBuckets(0.001, 4, 10) // 1ms ~ 262s
+
+func newMetrics() *metrics {
+	m := &metrics{
+		_reg: prometheus.NewRegistry(),
+		_holders: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "rsmap_resource_holders",
+			Help: "Number of operators holding the lock of the resource.",
+		}, []string{"resource"}),
+		_waiters: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "rsmap_resource_waiters",
+			Help: "Number of operators waiting for the lock of the resource.",
+		}, []string{"resource"}),
+		_wait: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "rsmap_acquisition_wait_seconds",
+			Help:    "Time spent waiting for the lock of the resource.",
+			Buckets: durationBuckets,
+		}, []string{"resource", "mode"}),
+		_hold: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "rsmap_acquisition_hold_seconds",
+			Help:    "Time the lock of the resource was held.",
+			Buckets: durationBuckets,
+		}, []string{"resource"}),
+		_init: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "rsmap_init_duration_seconds",
+			Help:    "Time spent for the initialization of the resource.",
+			Buckets: durationBuckets,
+		}, []string{"resource", "result"}),
+		_failovers: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "rsmap_server_failovers_total",
+			Help: "Number of server launches taking over the former server in this execution.",
+		}),
+		_acquired:  map[string]time.Time{},
+		_initiated: map[string]time.Time{},
+	}
+	m._reg.MustRegister(
+		m._holders,
+		m._waiters,
+		m._wait,
+		m._hold,
+		m._init,
+		m._failovers,
+	)
+	return m
+}
+
+func (m *metrics) handler() http.Handler {
+	return promhttp.HandlerFor(m._reg, promhttp.HandlerOpts{})
+}
+
+func (m *metrics) failovers(n int) {
+	if m == nil {
+		return
+	}
+	m._failovers.Add(float64(n))
+}
+
+// Set holders replayed from the logs.
+func (m *metrics) replayed(resourceName string, holders int) {
+	if m == nil {
+		return
+	}
+	m._holders.WithLabelValues(resourceName).Set(float64(holders))
+}
+
+func (m *metrics) initStarted(resourceName, operator string) {
+	if m == nil {
+		return
+	}
+	m._mu.Lock()
+	m._initiated[metricsKey(resourceName, operator)] = time.Now()
+	m._mu.Unlock()
+}
+
+func (m *metrics) initFinished(resourceName, operator string, completed bool) {
+	if m == nil {
+		return
+	}
+	m._mu.Lock()
+	start, ok := m._initiated[metricsKey(resourceName, operator)]
+	delete(m._initiated, metricsKey(resourceName, operator))
+	m._mu.Unlock()
+	if !ok {
+		return
+	}
+
+	result := "failed"
+	if completed {
+		result = "completed"
+	}
+	m._init.WithLabelValues(resourceName, result).Observe(time.Since(start).Seconds())
+}
+
+func (m *metrics) acquiring(resourceName string) {
+	if m == nil {
+		return
+	}
+	m._waiters.WithLabelValues(resourceName).Inc()
+}
+
+// Record the end of waiting. If acquired is false, the acquisition is canceled.
+func (m *metrics) acquired(resourceName, operator string, exclusive bool, start time.Time, acquired bool) {
+	if m == nil {
+		return
+	}
+	m._waiters.WithLabelValues(resourceName).Dec()
+	if !acquired {
+		return
+	}
+
+	now := time.Now()
+	m._wait.WithLabelValues(resourceName, modeString(exclusive)).Observe(now.Sub(start).Seconds())
+	m._holders.WithLabelValues(resourceName).Inc()
+
+	m._mu.Lock()
+	m._acquired[metricsKey(resourceName, operator)] = now
+	m._mu.Unlock()
+}
+
+func (m *metrics) released(resourceName, operator string) {
+	if m == nil {
+		return
+	}
+	m._holders.WithLabelValues(resourceName).Dec()
+
+	m._mu.Lock()
+	start, ok := m._acquired[metricsKey(resourceName, operator)]
+	delete(m._acquired, metricsKey(resourceName, operator))
+	m._mu.Unlock()
+	if ok { // Holding time of replayed acquisition is unknown.
+		m._hold.WithLabelValues(resourceName).Observe(time.Since(start).Seconds())
+	}
+}
+
+func metricsKey(resourceName, operator string) string {
+	return resourceName + "\x00" + operator
+}
@@ -0,0 +1,76 @@
+package rsmap
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/poll"
+)
+
+func TestServer_Metrics(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	ctx, cancel := context.WithCancel(background)
+	t.Cleanup(cancel)
+	go func() {
+		_ = Serve(ctx, dir, WithExecutionID("metrics"))
+	}()
+	var addr string
+	poll.WaitOn(t, func(t poll.LogT) poll.Result {
+		data, err := os.ReadFile(filepath.Join(dir, "metrics", "daemon"))
+		if err != nil {
+			return poll.Continue("server is not launched yet: %s", err)
+		}
+		addr = string(data)
+		return poll.Success()
+	})
+
+	m, err := New(dir, WithExecutionID("metrics"))
+	assert.NilError(t, err)
+	t.Cleanup(m.Close)
+
+	r, err := m.Resource(background, "treasure", WithInit(func(ctx context.Context) error {
+		return nil
+	}))
+	assert.NilError(t, err)
+	assert.NilError(t, r.RLock(background))
+
+	scrape := func(t *testing.T) string {
+		t.Helper()
+
+		resp, err := http.Get(addr + metricsPath)
+		assert.NilError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, resp.StatusCode, http.StatusOK)
+		body, err := io.ReadAll(resp.Body)
+		assert.NilError(t, err)
+		return string(body)
+	}
+
+	body := scrape(t)
+	for _, line := range []string{
+		`rsmap_resource_holders{resource="treasure"} 1`,
+		`rsmap_resource_waiters{resource="treasure"} 0`,
+		`rsmap_acquisition_wait_seconds_count{mode="shared",resource="treasure"} 1`,
+		`rsmap_init_duration_seconds_count{resource="treasure",result="completed"} 1`,
+		`rsmap_server_failovers_total 0`,
+	} {
+		assert.Assert(t, strings.Contains(body, line), "%q not found in:\n%s", line, body)
+	}
+
+	assert.NilError(t, r.UnlockAny())
+	body = scrape(t)
+	for _, line := range []string{
+		`rsmap_resource_holders{resource="treasure"} 0`,
+		`rsmap_acquisition_hold_seconds_count{resource="treasure"} 1`,
+	} {
+		assert.Assert(t, strings.Contains(body, line), "%q not found in:\n%s", line, body)
+	}
+}
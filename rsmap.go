@@ -4,19 +4,23 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/lestrrat-go/backoff/v2"
 	"github.com/lestrrat-go/option"
-	"go.etcd.io/bbolt"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
-	logsv1 "github.com/daichitakahashi/rsmap/internal/proto/logs/v1"
 	resource_mapv1 "github.com/daichitakahashi/rsmap/internal/proto/resource_map/v1"
 	"github.com/daichitakahashi/rsmap/logs"
 )
@@ -33,10 +37,12 @@ type (
 
 	// Resource brings an ability of acquire/release lock for the dedicated resource.
 	Resource struct {
-		_callers logs.CallerContext
-		_m       *Map
-		_max     int64
-		_name    string
+		_operator logs.Operator
+		_m        *Map
+		_max      int64
+		_name     string
+		_mu       sync.Mutex
+		_hold     trace.Span
 	}
 )
 
@@ -46,6 +52,16 @@ type (
 	}
 	identOptionRetryPolicy struct{}
 	identOptionHTTPClient  struct{}
+	identOptionExecutionID struct{}
+	identOptionIdleTimeout struct{}
+	identOptionServerAddr  struct{}
+	identOptionListenAddr  struct{}
+	identOptionBackend     struct{}
+	identOptionFileLock    struct{}
+	identOptionTracer      struct{}
+	identOptionLogger      struct{}
+	identOptionDashboard   struct{}
+	identOptionRetention   struct{}
 )
 
 // WithRetryPolicy specifies a retry policy of each operations(resource initializations, lock acquisitions).
@@ -65,11 +81,127 @@ func WithHTTPClient(c *http.Client) *NewOption {
 	}
 }
 
+// WithExecutionID specifies the execution ID explicitly.
+// It takes precedence over `RSMAP_EXECUTION_ID` environment variable.
+func WithExecutionID(id string) *NewOption {
+	return &NewOption{
+		Interface: option.New(identOptionExecutionID{}, id),
+	}
+}
+
+// WithIdleTimeout specifies the duration after which the server launched by [Serve] stops,
+// when it has no requests, no acquired locks and no initialization in progress.
+// This option is ignored by [New].
+func WithIdleTimeout(d time.Duration) *NewOption {
+	return &NewOption{
+		Interface: option.New(identOptionIdleTimeout{}, d),
+	}
+}
+
+// WithServerAddr specifies the address of the running server(e.g. "http://127.0.0.1:8080").
+// It takes precedence over `RSMAP_SERVER_ADDR` environment variable.
+//
+// If the address is specified, [Map] never launches server and never reads `addr` file.
+// [New] fails if the server does not become healthy within 5 seconds.
+// This is useful when `addr` file is not shared between the server and clients, such as tests running inside containers.
+func WithServerAddr(addr string) *NewOption {
+	return &NewOption{
+		Interface: option.New(identOptionServerAddr{}, addr),
+	}
+}
+
+// WithListenAddr specifies the TCP address for the server to listen on(e.g. ":8080").
+// By default, the server listens on a random port of the loopback address.
+// Admin operations, such as force-release by `rsmapctl`, are accepted only from the loopback address regardless of this option.
+// This is useful to make the address of the daemon launched by [Serve] predictable.
+func WithListenAddr(addr string) *NewOption {
+	return &NewOption{
+		Interface: option.New(identOptionListenAddr{}, addr),
+	}
+}
+
+// WithBackend specifies the function to open the backend, that persists the state of the server.
+// The function receives the directory `${rsmapDir}/${executionID}/`, and should give up opening when ctx is done.
+// By default, [BoltBackend] is used.
+func WithBackend(open func(ctx context.Context, dir string) (logs.Backend, error)) *NewOption {
+	return &NewOption{
+		Interface: option.New(identOptionBackend{}, open),
+	}
+}
+
+// BoltBackend opens the backend using bbolt database `logs.db`.
+func BoltBackend(ctx context.Context, dir string) (logs.Backend, error) {
+	return logs.OpenBoltBackend(ctx, filepath.Join(dir, "logs.db"))
+}
+
+// MemoryBackend opens the backend on memory. It is fast, but the state is not shared between processes.
+// So, use this only for single process execution and testing.
+func MemoryBackend(ctx context.Context, dir string) (logs.Backend, error) {
+	return logs.OpenMemoryBackend(ctx, dir)
+}
+
+// JournalBackend opens the backend using append-only journal file `logs.journal`.
+func JournalBackend(ctx context.Context, dir string) (logs.Backend, error) {
+	return logs.OpenJournalBackend(ctx, filepath.Join(dir, "logs.journal"))
+}
+
+// WithFileLock enables serverless mode, that uses OS file locks(flock) instead of server.
+// Lock files are placed under `${rsmapDir}/${executionID}/locks/`, and the events are still recorded to the backend.
+//
+// Because the locks are released by OS when the process exits, no failover is required.
+// All Map sharing the same directory must enable this option. Otherwise, we cannot provide correct control.
+func WithFileLock() *NewOption {
+	return &NewOption{
+		Interface: option.New(identOptionFileLock{}, true),
+	}
+}
+
+// WithTracerProvider specifies [trace.TracerProvider] to create spans of resource initializations,
+// waiting for locks, and holding them.
+// Trace context is propagated to the server, so the waiting on the server side appears under the caller's span.
+// By default, no span is created.
+func WithTracerProvider(tp trace.TracerProvider) *NewOption {
+	return &NewOption{
+		Interface: option.New(identOptionTracer{}, tp),
+	}
+}
+
+// WithLogger specifies [slog.Logger] to emit structured records of server launch and stop, retries of requests with their causes,
+// reads of server address, resource initializations, and lock acquisitions and releases.
+// By default, nothing is logged.
+func WithLogger(l *slog.Logger) *NewOption {
+	return &NewOption{
+		Interface: option.New(identOptionLogger{}, l),
+	}
+}
+
+// WithDashboard enables the web dashboard served by the server, at the root of the address written in `addr` file.
+// The dashboard shows holders, waiters and init state of each resource, and the timeline of events, updated live.
+func WithDashboard() *NewOption {
+	return &NewOption{
+		Interface: option.New(identOptionDashboard{}, true),
+	}
+}
+
+// WithRetention enables removal of old execution directories `${rsmapDir}/${executionID}/` when the server launches.
+// Directories older than maxAge, and directories exceeding maxCount from the newest one are removed.
+// If maxAge or maxCount is zero, it is not limited.
+// The directory in use by a live process is never removed. See also [GC].
+func WithRetention(maxAge time.Duration, maxCount int) *NewOption {
+	return &NewOption{
+		Interface: option.New(identOptionRetention{}, retention{
+			maxAge:   maxAge,
+			maxCount: maxCount,
+		}),
+	}
+}
+
 const (
 	EnvExecutionID = "RSMAP_EXECUTION_ID"
+	EnvServerAddr  = "RSMAP_SERVER_ADDR"
 )
 
-func logsDir(base string) (string, error) {
+func logsDir(base, executionID string) (string, error) {
 	if !filepath.IsAbs(base) {
 		wd, err := os.Getwd()
 		if err != nil {
@@ -78,9 +210,11 @@ func logsDir(base string) (string, error) {
 		base = filepath.Join(wd, base)
 	}
 	// Get execution ID.
-	executionID := strconv.Itoa(os.Getppid()) // The process of `go test`
-	if id, ok := os.LookupEnv(EnvExecutionID); ok {
-		executionID = id
+	if executionID == "" {
+		executionID = strconv.Itoa(os.Getppid()) // The process of `go test`
+		if id, ok := os.LookupEnv(EnvExecutionID); ok {
+			executionID = id
+		}
 	}
 	dir := filepath.Join(base, executionID)
 
@@ -99,6 +233,76 @@ func logsDir(base string) (string, error) {
 	return dir, nil
 }
 
+// Create config from options, and prepare the directory for `logs.db` and `addr`.
+func newConfig(rsmapDir string, opts []*NewOption) (config, string, error) {
+	cfg := config{
+		retryPolicy: backoff.NewConstantPolicy(
+			// FIXME: Reconsider default policy.
+			backoff.WithMaxRetries(200),
+			backoff.WithInterval(time.Millisecond*200),
+		),
+		httpCli:    &http.Client{},
+		serverAddr: os.Getenv(EnvServerAddr),
+		listenAddr: "127.0.0.1:0",
+		backend:    BoltBackend,
+		tracer:     noopTracer,
+		logger:     discardLogger,
+	}
+	var executionID string
+
+	// Apply options.
+	for _, opt := range opts {
+		switch opt.Ident() {
+		case identOptionRetryPolicy{}:
+			cfg.retryPolicy = opt.Value().(backoff.Policy)
+		case identOptionHTTPClient{}:
+			cfg.httpCli = opt.Value().(*http.Client)
+		case identOptionExecutionID{}:
+			executionID = opt.Value().(string)
+		case identOptionIdleTimeout{}:
+			cfg.idleTimeout = opt.Value().(time.Duration)
+		case identOptionServerAddr{}:
+			cfg.serverAddr = opt.Value().(string)
+		case identOptionListenAddr{}:
+			cfg.listenAddr = opt.Value().(string)
+		case identOptionBackend{}:
+			cfg.backend = opt.Value().(func(ctx context.Context, dir string) (logs.Backend, error))
+		case identOptionFileLock{}:
+			cfg.fileLock = opt.Value().(bool)
+		case identOptionTracer{}:
+			cfg.tracer = opt.Value().(trace.TracerProvider).Tracer(tracerName)
+		case identOptionLogger{}:
+			cfg.logger = opt.Value().(*slog.Logger)
+		case identOptionDashboard{}:
+			cfg.dashboard = opt.Value().(bool)
+		case identOptionRetention{}:
+			cfg.retention = opt.Value().(retention)
+		}
+	}
+	if cfg.serverAddr != "" {
+		u, err := url.Parse(cfg.serverAddr)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return config{}, "", fmt.Errorf("rsmap: invalid server address: %q", cfg.serverAddr)
+		}
+	}
+
+	// Get directory for `logs.db` and `addr`.
+	dir, err := logsDir(rsmapDir, executionID)
+	if err != nil {
+		return config{}, "", err
+	}
+
+	// Create directory if not exists.
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return config{}, "", fmt.Errorf("rsmap: failed to prepare directory(rsmapDir): %w", err)
+	}
+
+	cfg.dir = dir
+	cfg.addrFile = filepath.Join(dir, "addr")
+	cfg.daemonFile = filepath.Join(dir, "daemon")
+	return cfg, dir, nil
+}
+
 // New creates an instance of [Map] that enables us to reuse external resources with thread safety.
 // Most common use-case is Go's parallelized testing of multiple packages (`go test -p=N ./...`.)
 //
@@ -109,53 +313,62 @@ func logsDir(base string) (string, error) {
 // It's user's responsibility.
 //
 // `executionID` is the identifier of the execution of `go test`. In default, we use the value of [os.Getppid()].
-// If you want to specify the id explicitly, set the value to `RSMAP_EXECUTION_ID` environment variable.
+// If you want to specify the id explicitly, set the value to `RSMAP_EXECUTION_ID` environment variable, or use [WithExecutionID].
+//
+// If the daemon launched by [Serve] (or `rsmapd` command) is running for the same directory, Map doesn't launch server,
+// and acts as a pure client. If the daemon stops while Map is in use (e.g. by [WithIdleTimeout]), Map launches server as usual.
+// Also, if the server address is specified by [WithServerAddr] or `RSMAP_SERVER_ADDR` environment variable,
+// Map connects to the server after checking its health.
 //
 // In almost cases, following code can be helpful.
 //
 //	p,  _ := exec.Command("go", "mod", "GOMOD").Output() // Get file path of "go.mod".
 //	m, _ := rsmap.New(filepath.Join(filepath.Dir(strings.TrimSpace(string(p))), ".rsmap"))
 func New(rsmapDir string, opts ...*NewOption) (*Map, error) {
-	_, file, line, _ := runtime.Caller(1)
 	var callers logs.CallerContext
-	callers = callers.Append(file, line)
+	callers = callers.AppendFrame(callerFrame())
 
-	// Get directory for `logs.db` and `addr`.
-	dir, err := logsDir(rsmapDir)
+	cfg, dir, err := newConfig(rsmapDir, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	// Create directory if not exists.
-	if err = os.MkdirAll(dir, 0755); err != nil {
-		return nil, fmt.Errorf("rsmap: failed to prepare directory(rsmapDir): %w", err)
+	m := &Map{
+		_callers: callers,
+		_cfg:     cfg,
+		_rm:      newClientSideMap(cfg),
 	}
 
-	cfg := config{
-		dbFile:   filepath.Join(dir, "logs.db"),
-		addrFile: filepath.Join(dir, "addr"),
-		retryPolicy: backoff.NewConstantPolicy(
-			// FIXME: Reconsider default policy.
-			backoff.WithMaxRetries(200),
-			backoff.WithInterval(time.Millisecond*200),
-		),
-		httpCli: &http.Client{},
+	// In serverless mode, use file locks directly.
+	if cfg.fileLock {
+		if err := cfg.checkSchema(); err != nil {
+			return nil, err
+		}
+		rm := newFileLockMap(cfg)
+		m._rm = rm
+		m._stop = sync.OnceFunc(rm.close)
+		return m, nil
 	}
 
-	// Apply options.
-	for _, opt := range opts {
-		switch opt.Ident() {
-		case identOptionRetryPolicy{}:
-			cfg.retryPolicy = opt.Value().(backoff.Policy)
-		case identOptionHTTPClient{}:
-			cfg.httpCli = opt.Value().(*http.Client)
+	// If the server address is specified explicitly, act as a pure client.
+	if cfg.serverAddr != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+		defer cancel()
+		if err := m._rm.(*clientSideMap).waitHealthy(ctx); err != nil {
+			return nil, err
 		}
+		m._stop = func() {}
+		return m, nil
 	}
 
-	m := &Map{
-		_callers: callers,
-		_cfg:     cfg,
-		_rm:      newClientSideMap(cfg),
+	// If the daemon is running, act as a pure client until the daemon stops.
+	if cfg.daemonRunning() {
+		m._stop = m.followDaemon(dir)
+		return m, nil
+	}
+
+	if err := cfg.checkSchema(); err != nil {
+		return nil, err
 	}
 
 	// Start server launch process, and set release function.
@@ -181,6 +394,7 @@ type (
 	}
 	identOptionParallelism struct{}
 	identOptionInit        struct{}
+	identOptionTest        struct{}
 )
 
 // WithMaxParallelism specifies max parallelism of the resource usage.
@@ -203,13 +417,25 @@ func WithInit(init InitFunc) *ResourceOption {
 	}
 }
 
+// WithTest records the name of the test, such as [testing.T], as a part of the caller context of the resource.
+// It allows us to identify the test in the logs, and filter the logs by `viewlogs --test`.
+func WithTest(t interface{ Name() string }) *ResourceOption {
+	return &ResourceOption{
+		Interface: option.New(identOptionTest{}, t.Name()),
+	}
+}
+
 // Resource creates [Resource] object that provides control for resource usage.
 //
 // Resource has a setting for max parallelism, you can specify the value by [WithMaxParallelism](default value is 5.)
 // And you want to perform an initialization of the resource, use [WithInit].
-func (m *Map) Resource(ctx context.Context, name string, opts ...*ResourceOption) (*Resource, error) {
-	_, file, line, _ := runtime.Caller(1)
-	callers := m._callers.Append(file, line)
+func (m *Map) Resource(ctx context.Context, name string, opts ...*ResourceOption) (_ *Resource, err error) {
+	callers := m._callers.AppendFrame(callerFrame())
+
+	ctx, span := m._cfg.tracer.Start(ctx, "rsmap.Resource", trace.WithAttributes(resourceAttr(name)))
+	defer func() {
+		endSpan(span, err)
+	}()
 
 	var (
 		n             = int64(5)
@@ -226,18 +452,28 @@ func (m *Map) Resource(ctx context.Context, name string, opts ...*ResourceOption
 			n = opt.Value().(int64)
 		case identOptionInit{}:
 			init = opt.Value().(InitFunc)
+		case identOptionTest{}:
+			callers = callers.WithTest(opt.Value().(string))
 		}
 	}
+	operator := logs.NewOperator(callers)
 	m._mu.RLock()
 	rm := m._rm
 	m._mu.RUnlock()
-	try, err := rm.tryInit(ctx, name, callers)
+	try, err := rm.tryInit(ctx, name, operator)
 	if err != nil {
 		return nil, err
 	}
 	if try {
+		logger := m._cfg.logger.With("resource", name, "operator", callers.String())
+
 		// Initialization of the resource.
 		err = func() (err error) {
+			ctx, span := m._cfg.tracer.Start(ctx, "rsmap.init", trace.WithAttributes(resourceAttr(name)))
+			defer func() {
+				endSpan(span, err)
+			}()
+
 			var notPanicked bool
 			defer func() {
 				m._mu.RLock()
@@ -246,7 +482,12 @@ func (m *Map) Resource(ctx context.Context, name string, opts ...*ResourceOption
 
 				// If init succeeds, mark as complete.
 				if notPanicked && err == nil {
-					err = rm.completeInit(ctx, name, callers)
+					err = rm.completeInit(ctx, name, operator)
+					if err != nil {
+						logger.Error("rsmap: failed to complete init", "error", err)
+						return
+					}
+					logger.Info("rsmap: init completed")
 					return
 				}
 
@@ -254,10 +495,12 @@ func (m *Map) Resource(ctx context.Context, name string, opts ...*ResourceOption
 				// CAUTION: Do not recover panic to preserve stacktrace.
 				err = errors.Join(
 					err,
-					rm.failInit(ctx, name, callers),
+					rm.failInit(ctx, name, operator),
 				)
+				logger.Warn("rsmap: init failed", "error", err, "panicked", !notPanicked)
 			}()
 
+			logger.Info("rsmap: init started")
 			err = init(ctx)
 			notPanicked = true
 			return
@@ -268,10 +511,10 @@ func (m *Map) Resource(ctx context.Context, name string, opts ...*ResourceOption
 	}
 
 	return &Resource{
-		_callers: callers,
-		_m:       m,
-		_max:     n,
-		_name:    name,
+		_operator: operator,
+		_m:        m,
+		_max:      n,
+		_name:     name,
 	}, nil
 }
 
@@ -281,7 +524,7 @@ func (m *Map) Resource(ctx context.Context, name string, opts ...*ResourceOption
 //
 // To release lock, use [UnlockAny].
 func (r *Resource) RLock(ctx context.Context) error {
-	return r._m.resourceMap().acquire(ctx, r._name, r._callers, r._max, false)
+	return r.lock(ctx, "rsmap.RLock", false)
 }
 
 // Lock acquires exclusive lock of the Resource.
@@ -290,12 +533,57 @@ func (r *Resource) RLock(ctx context.Context) error {
 //
 // To release lock, use [UnlockAny].
 func (r *Resource) Lock(ctx context.Context) error {
-	return r._m.resourceMap().acquire(ctx, r._name, r._callers, r._max, true)
+	return r.lock(ctx, "rsmap.Lock", true)
+}
+
+func (r *Resource) lock(ctx context.Context, spanName string, exclusive bool) error {
+	tracer := r._m._cfg.tracer
+	attrs := trace.WithAttributes(resourceAttr(r._name), modeAttr(exclusive))
+
+	logger := r._m._cfg.logger.With("resource", r._name, "mode", modeString(exclusive), "operator", r._operator.Context.String())
+
+	logger.Debug("rsmap: acquiring lock")
+	start := time.Now()
+	waitCtx, span := tracer.Start(ctx, spanName, attrs)
+	err := r._m.resourceMap().acquire(waitCtx, r._name, r._operator, r._max, exclusive)
+	endSpan(span, err)
+	if err != nil {
+		logger.Warn("rsmap: failed to acquire lock", "wait", time.Since(start), "error", err)
+		return err
+	}
+	logger.Info("rsmap: lock acquired", "wait", time.Since(start))
+
+	// Span of holding the lock, ended by UnlockAny.
+	r._mu.Lock()
+	if r._hold == nil {
+		_, r._hold = tracer.Start(ctx, "rsmap.hold", attrs)
+	}
+	r._mu.Unlock()
+	return nil
 }
 
 // UnlockAny releases acquired shared/exclusive lock by the Resource.
 func (r *Resource) UnlockAny() error {
-	return r._m.resourceMap().release(context.Background(), r._name, r._callers)
+	r._mu.Lock()
+	defer r._mu.Unlock()
+
+	// Release under the span of holding.
+	ctx := context.Background()
+	if r._hold != nil {
+		ctx = trace.ContextWithSpan(ctx, r._hold)
+	}
+	logger := r._m._cfg.logger.With("resource", r._name, "operator", r._operator.Context.String())
+	err := r._m.resourceMap().release(ctx, r._name, r._operator)
+	if err != nil {
+		logger.Warn("rsmap: failed to release lock", "error", err)
+		return err
+	}
+	logger.Info("rsmap: lock released")
+	if r._hold != nil {
+		r._hold.End()
+		r._hold = nil
+	}
+	return nil
 }
 
 type ResourceLocker struct {
@@ -322,7 +610,12 @@ func (r *Resource) Shared() *ResourceLocker {
 // LockResources acquires exclusive/shared locks for multiple resources.
 // Returned function releases all locks acquired.
 func LockResources(ctx context.Context, resources ...*ResourceLocker) (func() error, error) {
-	var m resourceMap
+	var (
+		m      resourceMap
+		tracer = noopTracer
+		logger = discardLogger
+		names  = make([]string, 0, len(resources))
+	)
 	acquireEntries := make([]*resource_mapv1.AcquireMultiEntry, 0, len(resources))
 	releaseEntries := make([]*resource_mapv1.ReleaseMultiEntry, 0, len(resources))
 
@@ -330,39 +623,62 @@ func LockResources(ctx context.Context, resources ...*ResourceLocker) (func() er
 		mm := r._r._m.resourceMap()
 		if m == nil {
 			m = mm
+			tracer = r._r._m._cfg.tracer
+			logger = r._r._m._cfg.logger
 		} else if m != mm {
 			return nil, errors.New("rsmap: all ResourceLocker must be derived from same Map")
 		}
+		names = append(names, r._r._name)
 
 		acquireEntries = append(acquireEntries, &resource_mapv1.AcquireMultiEntry{
 			ResourceName:   r._r._name,
-			Context:        r._r._callers,
+			Context:        r._r._operator.Context,
+			OperatorId:     r._r._operator.ID,
 			MaxParallelism: r._r._max,
 			Exclusive:      r._exclusive,
 		})
 		releaseEntries = append(releaseEntries, &resource_mapv1.ReleaseMultiEntry{
 			ResourceName: r._r._name,
-			Context:      r._r._callers,
+			Context:      r._r._operator.Context,
+			OperatorId:   r._r._operator.ID,
 		})
 	}
-	err := m.acquireMulti(ctx, acquireEntries)
+	logger = logger.With("resources", names)
+
+	logger.Debug("rsmap: acquiring locks")
+	start := time.Now()
+	attrs := trace.WithAttributes(attribute.StringSlice("rsmap.resources", names))
+	waitCtx, span := tracer.Start(ctx, "rsmap.LockResources", attrs)
+	err := m.acquireMulti(waitCtx, acquireEntries)
+	endSpan(span, err)
 	if err != nil {
+		logger.Warn("rsmap: failed to acquire locks", "wait", time.Since(start), "error", err)
 		return nil, err
 	}
+	logger.Info("rsmap: locks acquired", "wait", time.Since(start))
 
+	// Span of holding the locks, ended by returned function.
+	_, hold := tracer.Start(ctx, "rsmap.hold", attrs)
 	return func() error {
-		return m.releaseMulti(context.Background(), releaseEntries)
+		err := m.releaseMulti(trace.ContextWithSpan(context.Background(), hold), releaseEntries)
+		endSpan(hold, err)
+		if err != nil {
+			logger.Warn("rsmap: failed to release locks", "error", err)
+			return err
+		}
+		logger.Info("rsmap: locks released")
+		return nil
 	}, nil
 }
 
 // Core interface for control operations for both server and client side.
 type resourceMap interface {
-	tryInit(ctx context.Context, resourceName string, operator logs.CallerContext) (bool, error)
-	completeInit(ctx context.Context, resourceName string, operator logs.CallerContext) error
-	failInit(ctx context.Context, resourceName string, operator logs.CallerContext) error
-	acquire(ctx context.Context, resourceName string, operator logs.CallerContext, max int64, exclusive bool) error
+	tryInit(ctx context.Context, resourceName string, operator logs.Operator) (bool, error)
+	completeInit(ctx context.Context, resourceName string, operator logs.Operator) error
+	failInit(ctx context.Context, resourceName string, operator logs.Operator) error
+	acquire(ctx context.Context, resourceName string, operator logs.Operator, max int64, exclusive bool) error
 	acquireMulti(ctx context.Context, resources []*resource_mapv1.AcquireMultiEntry) error
-	release(ctx context.Context, resourceName string, operator logs.CallerContext) error
+	release(ctx context.Context, resourceName string, operator logs.Operator) error
 	releaseMulti(ctx context.Context, resources []*resource_mapv1.ReleaseMultiEntry) error
 }
 
@@ -372,45 +688,85 @@ type serverSideMap struct {
 }
 
 // Create resourceMap for server side.
-// This map reads and updates bbolt.DB directly.
-func newServerSideMap(db *bbolt.DB, closing <-chan struct{}) (*serverSideMap, error) {
-	initRecordStore, err := logs.NewResourceRecordStore[logsv1.InitRecord](db)
+// This map reads and updates logs.Backend directly.
+func newServerSideMap(b logs.Backend, closing <-chan struct{}) (*serverSideMap, error) {
+	init, err := loadInitController(b.InitRecordStore(), closing)
 	if err != nil {
 		return nil, err
 	}
-	acquireRecordStore, err := logs.NewResourceRecordStore[logsv1.AcquisitionRecord](db)
+	acquire, err := loadAcquireController(b.AcquisitionRecordStore(), time.Second*2, closing)
 	if err != nil {
 		return nil, err
 	}
 
-	init, err := loadInitController(initRecordStore, closing)
-	if err != nil {
+	return &serverSideMap{
+		_init:    init,
+		_acquire: acquire,
+	}, nil
+}
+
+// Enable tracing of waiting on the server side.
+func (m *serverSideMap) enableTracing(tracer trace.Tracer) {
+	m._acquire._tracer = tracer
+}
+
+// Get status of all resources, sorted by the name.
+func (m *serverSideMap) status() ([]*resource_mapv1.ResourceStatus, error) {
+	statuses := map[string]*resource_mapv1.ResourceStatus{}
+	if err := m._init.status(statuses); err != nil {
 		return nil, err
 	}
-	acquire, err := loadAcquireController(acquireRecordStore, time.Second*2, closing)
-	if err != nil {
+	if err := m._acquire.status(statuses); err != nil {
 		return nil, err
 	}
 
-	return &serverSideMap{
-		_init:    init,
-		_acquire: acquire,
-	}, nil
+	sorted := make([]*resource_mapv1.ResourceStatus, 0, len(statuses))
+	for _, st := range statuses {
+		sorted = append(sorted, st)
+	}
+	slices.SortFunc(sorted, func(a, b *resource_mapv1.ResourceStatus) int {
+		return strings.Compare(a.ResourceName, b.ResourceName)
+	})
+	return sorted, nil
+}
+
+// Enable publishing events to the broker, by wrapping the stores of controllers.
+func (m *serverSideMap) enableWatch(b *broker) {
+	m._init._store = &watchedInitStore{
+		ResourceRecordStore: m._init._store,
+		_broker:             b,
+	}
+	m._acquire._kv = &watchedAcquisitionStore{
+		ResourceRecordStore: m._acquire._kv,
+		_broker:             b,
+	}
 }
 
-func (m *serverSideMap) tryInit(ctx context.Context, resourceName string, operator logs.CallerContext) (bool, error) {
+// Enable metrics of controllers.
+func (m *serverSideMap) enableMetrics(mt *metrics) {
+	m._init._metrics = mt
+	m._acquire._metrics = mt
+
+	// Set replayed holders.
+	m._acquire._resources.Range(func(k, v any) bool {
+		mt.replayed(k.(string), v.(*resource).ctl.Operators())
+		return true
+	})
+}
+
+func (m *serverSideMap) tryInit(ctx context.Context, resourceName string, operator logs.Operator) (bool, error) {
 	return m._init.tryInit(ctx, resourceName, operator)
 }
 
-func (m *serverSideMap) completeInit(_ context.Context, resourceName string, operator logs.CallerContext) error {
+func (m *serverSideMap) completeInit(_ context.Context, resourceName string, operator logs.Operator) error {
 	return m._init.complete(resourceName, operator)
 }
 
-func (m *serverSideMap) failInit(_ context.Context, resourceName string, operator logs.CallerContext) error {
+func (m *serverSideMap) failInit(_ context.Context, resourceName string, operator logs.Operator) error {
 	return m._init.fail(resourceName, operator)
 }
 
-func (m *serverSideMap) acquire(ctx context.Context, resourceName string, operator logs.CallerContext, max int64, exclusive bool) error {
+func (m *serverSideMap) acquire(ctx context.Context, resourceName string, operator logs.Operator, max int64, exclusive bool) error {
 	return m._acquire.acquire(ctx, resourceName, operator, max, exclusive)
 }
 
@@ -418,7 +774,7 @@ func (m *serverSideMap) acquireMulti(ctx context.Context, resources []*resource_
 	return m._acquire.acquireMulti(ctx, resources)
 }
 
-func (m *serverSideMap) release(_ context.Context, resourceName string, operator logs.CallerContext) error {
+func (m *serverSideMap) release(_ context.Context, resourceName string, operator logs.Operator) error {
 	return m._acquire.release(resourceName, operator)
 }
 
@@ -427,3 +783,11 @@ func (m *serverSideMap) releaseMulti(_ context.Context, resources []*resource_ma
 }
 
 var _ resourceMap = (*serverSideMap)(nil)
+
+// Get the frame of the caller of the function calling this.
+func callerFrame() runtime.Frame {
+	pc := make([]uintptr, 1)
+	runtime.Callers(3, pc)
+	frame, _ := runtime.CallersFrames(pc).Next()
+	return frame
+}
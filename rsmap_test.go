@@ -3,6 +3,7 @@ package rsmap
 import (
 	"context"
 	"errors"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -14,8 +15,12 @@ import (
 
 	"github.com/lestrrat-go/backoff/v2"
 	"github.com/rs/xid"
+	"go.etcd.io/bbolt"
 	"golang.org/x/sync/errgroup"
 	"gotest.tools/v3/assert"
+	"gotest.tools/v3/poll"
+
+	"github.com/daichitakahashi/rsmap/logs"
 )
 
 var background = context.Background()
@@ -92,6 +97,129 @@ func TestNew(t *testing.T) {
 	})
 }
 
+func TestNew_ServerAddr(t *testing.T) {
+	t.Parallel()
+
+	// Launch server on other directory.
+	serverDir := t.TempDir()
+	ctx, cancel := context.WithCancel(background)
+	t.Cleanup(cancel)
+	go func() {
+		_ = Serve(ctx, serverDir, WithExecutionID("server"))
+	}()
+	var addr string
+	poll.WaitOn(t, func(t poll.LogT) poll.Result {
+		data, err := os.ReadFile(filepath.Join(serverDir, "server", "addr"))
+		if err != nil {
+			return poll.Continue("server is not launched yet: %s", err)
+		}
+		addr = string(data)
+		return poll.Success()
+	})
+
+	t.Run("WithServerAddr", func(t *testing.T) {
+		t.Parallel()
+
+		m, err := New(t.TempDir(), WithServerAddr(addr))
+		assert.NilError(t, err)
+		t.Cleanup(m.Close)
+
+		r, err := m.Resource(background, "treasure")
+		assert.NilError(t, err)
+		assert.NilError(t, r.Lock(background))
+		assert.NilError(t, r.UnlockAny())
+	})
+
+	t.Run("Unreachable server", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := New(t.TempDir(),
+			WithServerAddr("http://127.0.0.1:1"),
+			WithRetryPolicy(backoff.NewConstantPolicy(
+				backoff.WithInterval(time.Millisecond*10),
+				backoff.WithMaxRetries(2),
+			)),
+		)
+		assert.ErrorContains(t, err, "rsmap: server is unreachable(http://127.0.0.1:1)")
+	})
+
+	t.Run("Unreachable server with default retry policy", func(t *testing.T) {
+		t.Parallel()
+
+		start := time.Now()
+		_, err := New(t.TempDir(), WithServerAddr("http://127.0.0.1:1"))
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Assert(t, time.Since(start) < healthCheckTimeout*2)
+		// The cause is kept.
+		var opErr *net.OpError
+		assert.Assert(t, errors.As(err, &opErr), "%s", err)
+	})
+
+	t.Run("Invalid address", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := New(t.TempDir(), WithServerAddr("127.0.0.1:8080"))
+		assert.ErrorContains(t, err, "rsmap: invalid server address")
+	})
+}
+
+func TestNew_ServerAddrEnv(t *testing.T) {
+	t.Setenv(EnvServerAddr, "http://127.0.0.1:1")
+
+	_, err := New(t.TempDir(),
+		WithRetryPolicy(backoff.NewConstantPolicy(
+			backoff.WithInterval(time.Millisecond*10),
+			backoff.WithMaxRetries(2),
+		)),
+	)
+	assert.ErrorContains(t, err, "rsmap: server is unreachable(http://127.0.0.1:1)")
+}
+
+func TestNew_WithBackend(t *testing.T) {
+	t.Parallel()
+
+	backends := map[string]func(ctx context.Context, dir string) (logs.Backend, error){
+		"memory":  MemoryBackend,
+		"journal": JournalBackend,
+	}
+	for name, backend := range backends {
+		backend := backend
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			newMap := func(t *testing.T) *Map {
+				t.Helper()
+
+				m, err := New(dir, WithBackend(backend))
+				assert.NilError(t, err)
+				t.Cleanup(m.Close)
+				return m
+			}
+
+			var count int64
+			init := WithInit(func(ctx context.Context) error {
+				atomic.AddInt64(&count, 1)
+				return nil
+			})
+
+			r1, err := newMap(t).Resource(background, "treasure", init)
+			assert.NilError(t, err)
+			r2, err := newMap(t).Resource(background, "treasure", init)
+			assert.NilError(t, err)
+			assert.Equal(t, count, int64(1))
+
+			assert.NilError(t, r1.Lock(background))
+			ctx, cancel := context.WithTimeout(background, time.Millisecond*500)
+			defer cancel()
+			assert.ErrorIs(t, r2.Lock(ctx), context.DeadlineExceeded)
+			assert.NilError(t, r1.UnlockAny())
+			assert.NilError(t, r2.Lock(background))
+			assert.NilError(t, r2.UnlockAny())
+		})
+	}
+}
+
 func TestNew_FilesExistAsDirectory(t *testing.T) {
 	t.Run("logs.db", func(t *testing.T) {
 		t.Setenv(EnvExecutionID, "fixed")
@@ -116,6 +244,34 @@ func TestNew_FilesExistAsDirectory(t *testing.T) {
 	})
 }
 
+func TestNew_IncompatibleSchema(t *testing.T) {
+	t.Setenv(EnvExecutionID, "fixed")
+	dir := t.TempDir()
+
+	// Create logs.db written by the newer version.
+	assert.NilError(t, os.MkdirAll(filepath.Join(dir, "fixed"), 0755))
+	db, err := bbolt.Open(filepath.Join(dir, "fixed", "logs.db"), 0644, nil)
+	assert.NilError(t, err)
+	assert.NilError(t, db.Update(func(tx *bbolt.Tx) error {
+		b, err := tx.CreateBucket([]byte("info"))
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte("version"), []byte("999"))
+	}))
+	assert.NilError(t, db.Close())
+
+	_, err = New(dir)
+	assert.ErrorIs(t, err, logs.ErrIncompatibleSchema)
+
+	_, err = New(dir, WithFileLock())
+	assert.ErrorIs(t, err, logs.ErrIncompatibleSchema)
+
+	ctx, cancel := context.WithTimeout(background, time.Second)
+	defer cancel()
+	assert.ErrorIs(t, Serve(ctx, dir), logs.ErrIncompatibleSchema)
+}
+
 func TestMap_Resource(t *testing.T) {
 	t.Parallel()
 
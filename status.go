@@ -0,0 +1,129 @@
+package rsmap
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	connect_go "github.com/bufbuild/connect-go"
+
+	resource_mapv1 "github.com/daichitakahashi/rsmap/internal/proto/resource_map/v1"
+	"github.com/daichitakahashi/rsmap/internal/proto/resource_map/v1/resource_mapv1connect"
+	"github.com/daichitakahashi/rsmap/logs"
+)
+
+// InitState represents the state of the resource initialization.
+type InitState int
+
+const (
+	InitNotStarted InitState = iota
+	InitInProgress
+	InitCompleted
+	InitFailed
+)
+
+func (s InitState) String() string {
+	switch s {
+	case InitNotStarted:
+		return "not started"
+	case InitInProgress:
+		return "in progress"
+	case InitCompleted:
+		return "completed"
+	case InitFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+type (
+	// Status is the snapshot of the server.
+	Status struct {
+		Addr       string
+		LaunchedAt time.Time
+		// Sorted by the name.
+		Resources []ResourceStatus
+	}
+
+	// ResourceStatus is the snapshot of the resource.
+	ResourceStatus struct {
+		Name string
+		// Max parallelism. Zero if the resource is never acquired.
+		Max int64
+		// Operators holding the lock.
+		Holders []Acquisition
+		// Operators waiting for the lock, in the order of the queue.
+		Waiters []Acquisition
+		Init    InitState
+		// Operator of the last initialization.
+		InitOperator logs.CallerContext
+	}
+
+	// Acquisition is the lock held by or requested by the operator.
+	Acquisition struct {
+		Operator logs.CallerContext
+		// Weight of the lock. Exclusive lock takes max parallelism.
+		N int64
+	}
+)
+
+// Status returns the snapshot of the server: current holders and waiters of each resource, and the state of initializations.
+//
+// Status is not supported in serverless mode enabled by [WithFileLock].
+func (m *Map) Status(ctx context.Context) (*Status, error) {
+	if m._cfg.fileLock {
+		return nil, errors.New("rsmap: Status is not supported in serverless mode")
+	}
+
+	var resp *resource_mapv1.GetStatusResponse
+	err := newClientSideMap(m._cfg).try(ctx, func(ctx context.Context, cli resource_mapv1connect.ResourceMapServiceClient) error {
+		r, err := cli.GetStatus(ctx, connect_go.NewRequest(&resource_mapv1.GetStatusRequest{}))
+		if err != nil {
+			return err
+		}
+		resp = r.Msg
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return statusFromResponse(resp), nil
+}
+
+var initStatesFromProto = map[resource_mapv1.InitState]InitState{
+	resource_mapv1.InitState_INIT_STATE_NOT_STARTED: InitNotStarted,
+	resource_mapv1.InitState_INIT_STATE_IN_PROGRESS: InitInProgress,
+	resource_mapv1.InitState_INIT_STATE_COMPLETED:   InitCompleted,
+	resource_mapv1.InitState_INIT_STATE_FAILED:      InitFailed,
+}
+
+func statusFromResponse(resp *resource_mapv1.GetStatusResponse) *Status {
+	convert := func(acquisitions []*resource_mapv1.AcquisitionStatus) []Acquisition {
+		var converted []Acquisition
+		for _, a := range acquisitions {
+			converted = append(converted, Acquisition{
+				Operator: a.Context,
+				N:        a.N,
+			})
+		}
+		return converted
+	}
+
+	status := &Status{
+		Addr:       resp.Addr,
+		LaunchedAt: time.Unix(0, resp.LaunchedAt),
+		Resources:  make([]ResourceStatus, 0, len(resp.Resources)),
+	}
+	for _, r := range resp.Resources {
+		status.Resources = append(status.Resources, ResourceStatus{
+			Name:         r.ResourceName,
+			Max:          r.Max,
+			Holders:      convert(r.Holders),
+			Waiters:      convert(r.Waiters),
+			Init:         initStatesFromProto[r.InitState],
+			InitOperator: r.InitContext,
+		})
+	}
+	return status
+}
@@ -0,0 +1,114 @@
+package rsmap
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/poll"
+)
+
+func TestMap_Status(t *testing.T) {
+	t.Parallel()
+
+	m, err := New(t.TempDir(), WithExecutionID("status"))
+	assert.NilError(t, err)
+	t.Cleanup(m.Close)
+
+	r1, err := m.Resource(background, "treasure", WithMaxParallelism(2))
+	assert.NilError(t, err)
+	r2, err := m.Resource(background, "treasure", WithMaxParallelism(2))
+	assert.NilError(t, err)
+	assert.NilError(t, r1.RLock(background))
+
+	// r2 waits for the release of r1.
+	ctx, cancel := context.WithCancel(background)
+	defer cancel()
+	locked := asyncResult(func() error {
+		return r2.Lock(ctx)
+	})
+
+	// Initialization of "precious" is in progress.
+	initStarted := make(chan struct{})
+	initDone := make(chan struct{})
+	inited := asyncResult(func() error {
+		_, err := m.Resource(background, "precious", WithInit(func(ctx context.Context) error {
+			close(initStarted)
+			<-initDone
+			return nil
+		}))
+		return err
+	})
+	<-initStarted
+
+	var status *Status
+	poll.WaitOn(t, func(t poll.LogT) poll.Result {
+		status, err = m.Status(background)
+		if err != nil {
+			return poll.Error(err)
+		}
+		if len(status.Resources) < 2 || len(status.Resources[1].Waiters) == 0 {
+			return poll.Continue("r2 is not waiting yet")
+		}
+		return poll.Success()
+	})
+	assert.Assert(t, status.Addr != "")
+	assert.Assert(t, !status.LaunchedAt.IsZero())
+
+	precious := status.Resources[0]
+	assert.Equal(t, precious.Name, "precious")
+	assert.Equal(t, precious.Init, InitInProgress)
+	assert.Assert(t, len(precious.InitOperator) > 0)
+
+	treasure := status.Resources[1]
+	assert.Equal(t, treasure.Name, "treasure")
+	assert.Equal(t, treasure.Max, int64(2))
+	assert.Equal(t, treasure.Init, InitCompleted)
+	assert.Equal(t, len(treasure.Holders), 1)
+	assert.Equal(t, treasure.Holders[0].Operator.String(), r1._operator.Context.String())
+	assert.Equal(t, treasure.Holders[0].N, int64(1))
+	assert.Equal(t, len(treasure.Waiters), 1)
+	assert.Equal(t, treasure.Waiters[0].Operator.String(), r2._operator.Context.String())
+	assert.Equal(t, treasure.Waiters[0].N, int64(2))
+
+	// After release, r2 becomes the holder.
+	assert.NilError(t, r1.UnlockAny())
+	assert.NilError(t, <-locked)
+	close(initDone)
+	assert.NilError(t, <-inited)
+
+	status, err = m.Status(background)
+	assert.NilError(t, err)
+	assert.Equal(t, status.Resources[0].Init, InitCompleted)
+	treasure = status.Resources[1]
+	assert.Equal(t, len(treasure.Holders), 1)
+	assert.Equal(t, treasure.Holders[0].Operator.String(), r2._operator.Context.String())
+	assert.Equal(t, len(treasure.Waiters), 0)
+}
+
+func TestWithTest(t *testing.T) {
+	t.Parallel()
+
+	m, err := New(t.TempDir(), WithExecutionID("test"))
+	assert.NilError(t, err)
+	t.Cleanup(m.Close)
+
+	r, err := m.Resource(background, "treasure", WithTest(t))
+	assert.NilError(t, err)
+	assert.NilError(t, r.Lock(background))
+	t.Cleanup(func() {
+		_ = r.UnlockAny()
+	})
+
+	status, err := m.Status(background)
+	assert.NilError(t, err)
+	assert.Equal(t, len(status.Resources[0].Holders), 1)
+	operator := status.Resources[0].Holders[0].Operator
+	assert.Equal(t, len(operator), 2)
+	assert.Equal(t, operator.Test(), t.Name())
+	for _, c := range operator {
+		assert.Equal(t, c.Pid, int64(os.Getpid()))
+		assert.Equal(t, c.Package, "github.com/daichitakahashi/rsmap")
+	}
+}
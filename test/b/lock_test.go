@@ -21,7 +21,7 @@ func Test_Treasure(t *testing.T) {
 			assert.NilError(t, err)
 			t.Cleanup(m.Close)
 
-			r, err := m.Resource(test.Context(t), test.ResourceTreasure, test.Options()...)
+			r, err := m.Resource(test.Context(t), test.ResourceTreasure, test.Options(t)...)
 			assert.NilError(t, err)
 
 			if op == test.OpLock {
@@ -50,7 +50,7 @@ func Test_Precious(t *testing.T) {
 			assert.NilError(t, err)
 			t.Cleanup(m.Close)
 
-			r, err := m.Resource(test.Context(t), test.ResourcePrecious, test.Options()...)
+			r, err := m.Resource(test.Context(t), test.ResourcePrecious, test.Options(t)...)
 			assert.NilError(t, err)
 
 			if op == test.OpLock {
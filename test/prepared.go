@@ -34,8 +34,9 @@ const (
 	ResourcePrecious = "precious"
 )
 
-func Options() []*rsmap.ResourceOption {
+func Options(t *testing.T) []*rsmap.ResourceOption {
 	return []*rsmap.ResourceOption{
+		rsmap.WithTest(t),
 		rsmap.WithMaxParallelism(3),
 		rsmap.WithInit(func(ctx context.Context) error {
 			DoSomething()
@@ -0,0 +1,56 @@
+package rsmap
+
+import (
+	"context"
+
+	connect_go "github.com/bufbuild/connect-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const tracerName = "github.com/daichitakahashi/rsmap"
+
+// Trace context is always propagated in W3C format, regardless of the global propagator.
+var tracePropagator = propagation.TraceContext{}
+
+// Tracer used when no TracerProvider is specified.
+var noopTracer trace.Tracer = noop.NewTracerProvider().Tracer(tracerName)
+
+func resourceAttr(resourceName string) attribute.KeyValue {
+	return attribute.String("rsmap.resource", resourceName)
+}
+
+func modeAttr(exclusive bool) attribute.KeyValue {
+	return attribute.String("rsmap.mode", modeString(exclusive))
+}
+
+// End span with recording the error.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Interceptor propagating trace context over RPCs.
+// Client side injects the context into request headers, and server side creates the span under it.
+func traceInterceptor(tracer trace.Tracer) connect_go.UnaryInterceptorFunc {
+	return func(next connect_go.UnaryFunc) connect_go.UnaryFunc {
+		return func(ctx context.Context, req connect_go.AnyRequest) (connect_go.AnyResponse, error) {
+			if req.Spec().IsClient {
+				tracePropagator.Inject(ctx, propagation.HeaderCarrier(req.Header()))
+				return next(ctx, req)
+			}
+
+			ctx = tracePropagator.Extract(ctx, propagation.HeaderCarrier(req.Header()))
+			ctx, span := tracer.Start(ctx, req.Spec().Procedure, trace.WithSpanKind(trace.SpanKindServer))
+			resp, err := next(ctx, req)
+			endSpan(span, err)
+			return resp, err
+		}
+	}
+}
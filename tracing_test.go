@@ -0,0 +1,73 @@
+package rsmap
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/poll"
+)
+
+func TestWithTracerProvider(t *testing.T) {
+	t.Parallel()
+
+	rec := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))
+
+	// Launch server in other Map, to propagate trace context over RPCs.
+	dir := t.TempDir()
+	ctx, cancel := context.WithCancel(background)
+	t.Cleanup(cancel)
+	go func() {
+		_ = Serve(ctx, dir, WithExecutionID("tracing"), WithTracerProvider(tp))
+	}()
+	poll.WaitOn(t, func(t poll.LogT) poll.Result {
+		_, err := os.Stat(filepath.Join(dir, "tracing", "daemon"))
+		if err != nil {
+			return poll.Continue("server is not launched yet: %s", err)
+		}
+		return poll.Success()
+	})
+
+	m, err := New(dir, WithExecutionID("tracing"), WithTracerProvider(tp))
+	assert.NilError(t, err)
+	t.Cleanup(m.Close)
+
+	testCtx, testSpan := tp.Tracer("test").Start(background, "test")
+	r, err := m.Resource(testCtx, "treasure", WithInit(func(ctx context.Context) error {
+		return nil
+	}))
+	assert.NilError(t, err)
+	assert.NilError(t, r.Lock(testCtx))
+	assert.NilError(t, r.UnlockAny())
+	unlock, err := LockResources(testCtx, r.Shared())
+	assert.NilError(t, err)
+	assert.NilError(t, unlock())
+	testSpan.End()
+
+	traceID := testSpan.SpanContext().TraceID()
+	spans := map[string]int{}
+	for _, s := range rec.Ended() {
+		assert.Equal(t, s.SpanContext().TraceID(), traceID, "span %q is not under the test span", s.Name())
+		spans[s.Name()]++
+	}
+	assert.DeepEqual(t, spans, map[string]int{
+		"test":                1,
+		"rsmap.Resource":      1,
+		"rsmap.init":          1,
+		"rsmap.Lock":          1,
+		"rsmap.LockResources": 1,
+		"rsmap.hold":          2,
+		"rsmap.server.wait":   2,
+		"/internal.proto.resource_map.v1.ResourceMapService/TryInitResource":      1,
+		"/internal.proto.resource_map.v1.ResourceMapService/CompleteInitResource": 1,
+		"/internal.proto.resource_map.v1.ResourceMapService/Acquire":              1,
+		"/internal.proto.resource_map.v1.ResourceMapService/Release":              1,
+		"/internal.proto.resource_map.v1.ResourceMapService/AcquireMulti":         1,
+		"/internal.proto.resource_map.v1.ResourceMapService/ReleaseMulti":         1,
+	})
+}
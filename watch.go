@@ -0,0 +1,412 @@
+package rsmap
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	connect_go "github.com/bufbuild/connect-go"
+
+	logsv1 "github.com/daichitakahashi/rsmap/internal/proto/logs/v1"
+	resource_mapv1 "github.com/daichitakahashi/rsmap/internal/proto/resource_map/v1"
+	"github.com/daichitakahashi/rsmap/internal/proto/resource_map/v1/resource_mapv1connect"
+	"github.com/daichitakahashi/rsmap/logs"
+)
+
+// EventKind represents the kind of [Event].
+type EventKind int
+
+const (
+	EventServerLaunched EventKind = iota + 1
+	EventServerStopped
+	EventInitStarted
+	EventInitCompleted
+	EventInitFailed
+	EventAcquiring
+	EventAcquired
+	EventReleased
+	EventInitReset
+	EventCanceled
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case EventServerLaunched:
+		return "server launched"
+	case EventServerStopped:
+		return "server stopped"
+	case EventInitStarted:
+		return "init started"
+	case EventInitCompleted:
+		return "init completed"
+	case EventInitFailed:
+		return "init failed"
+	case EventAcquiring:
+		return "acquiring"
+	case EventAcquired:
+		return "acquired"
+	case EventReleased:
+		return "released"
+	case EventInitReset:
+		return "init reset"
+	case EventCanceled:
+		return "canceled"
+	default:
+		return "unknown"
+	}
+}
+
+type (
+	// Event is the event occurred on the server.
+	Event struct {
+		Kind EventKind
+		// Name of the resource. Empty for server events.
+		ResourceName string
+		// Caller that triggered the event.
+		Operator  logs.CallerContext
+		Timestamp time.Time
+		// Address of the server, for EventServerLaunched.
+		Addr string
+		// Number of acquired slots of max parallelism, for EventAcquired.
+		N int64
+		// Whether the event is caused by admin operation, such as force-release by `rsmapctl`.
+		Admin bool
+	}
+
+	// WatchFilter specifies events to be received by [Map.Watch].
+	WatchFilter struct {
+		// If empty, events of all resources are received.
+		// Server events are always received regardless of this field.
+		ResourceNames []string
+		// If empty, events of all kinds are received.
+		Kinds []EventKind
+	}
+)
+
+func (f WatchFilter) match(e Event) bool {
+	return len(f.Kinds) == 0 || slices.Contains(f.Kinds, e.Kind)
+}
+
+// Watch subscribes events occurred on the server, such as server launch, resource initializations and lock acquisitions.
+// Returned channel is closed when ctx is canceled, or the server becomes unreachable.
+//
+// When the server is switched by failover, Watch reconnects to the new server, and [EventServerLaunched] of it is received first.
+// Events occurred while reconnecting are not received.
+// If the receiver cannot keep up with events and too many of them are queued on the server, Watch also reconnects in the same way,
+// and the queued events are not received.
+//
+// Watch is not supported in serverless mode enabled by [WithFileLock].
+func (m *Map) Watch(ctx context.Context, filter WatchFilter) (<-chan Event, error) {
+	if m._cfg.fileLock {
+		return nil, errors.New("rsmap: Watch is not supported in serverless mode")
+	}
+
+	ch := make(chan Event)
+	go func() {
+		defer close(ch)
+
+		_ = newClientSideMap(m._cfg).watch(ctx, &resource_mapv1.WatchRequest{
+			ResourceNames: filter.ResourceNames,
+		}, func(resp *resource_mapv1.WatchResponse) error {
+			e := eventFromResponse(resp)
+			if !filter.match(e) {
+				return nil
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case ch <- e:
+				return nil
+			}
+		})
+	}()
+	return ch, nil
+}
+
+func eventFromResponse(resp *resource_mapv1.WatchResponse) Event {
+	e := Event{
+		ResourceName: resp.ResourceName,
+	}
+	switch ev := resp.Event.(type) {
+	case *resource_mapv1.WatchResponse_Server:
+		e.Operator = ev.Server.Context
+		e.Timestamp = time.Unix(0, ev.Server.Timestamp)
+		e.Addr = ev.Server.Addr
+		switch ev.Server.Event {
+		case logsv1.ServerEvent_SERVER_EVENT_LAUNCHED:
+			e.Kind = EventServerLaunched
+		case logsv1.ServerEvent_SERVER_EVENT_STOPPED:
+			e.Kind = EventServerStopped
+		}
+	case *resource_mapv1.WatchResponse_Init:
+		e.Operator = ev.Init.Context
+		e.Timestamp = time.Unix(0, ev.Init.Timestamp)
+		e.Admin = ev.Init.Admin
+		switch ev.Init.Event {
+		case logsv1.InitEvent_INIT_EVENT_STARTED:
+			e.Kind = EventInitStarted
+		case logsv1.InitEvent_INIT_EVENT_COMPLETED:
+			e.Kind = EventInitCompleted
+		case logsv1.InitEvent_INIT_EVENT_FAILED:
+			e.Kind = EventInitFailed
+		case logsv1.InitEvent_INIT_EVENT_RESET:
+			e.Kind = EventInitReset
+		}
+	case *resource_mapv1.WatchResponse_Acquisition:
+		e.Operator = ev.Acquisition.Context
+		e.Timestamp = time.Unix(0, ev.Acquisition.Timestamp)
+		e.N = ev.Acquisition.N
+		e.Admin = ev.Acquisition.Admin
+		switch ev.Acquisition.Event {
+		case logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRING:
+			e.Kind = EventAcquiring
+		case logsv1.AcquisitionEvent_ACQUISITION_EVENT_ACQUIRED:
+			e.Kind = EventAcquired
+		case logsv1.AcquisitionEvent_ACQUISITION_EVENT_RELEASED:
+			e.Kind = EventReleased
+		case logsv1.AcquisitionEvent_ACQUISITION_EVENT_CANCELED:
+			e.Kind = EventCanceled
+		}
+	}
+	return e
+}
+
+// Watch events on the server. When the stream is ended by the server, reconnect to the (new) server.
+// This returns when ctx is canceled, fn returns error, or the server becomes unreachable.
+func (m *clientSideMap) watch(ctx context.Context, req *resource_mapv1.WatchRequest, fn func(resp *resource_mapv1.WatchResponse) error) error {
+	for {
+		var fnErr error
+		err := m.try(ctx, func(ctx context.Context, cli resource_mapv1connect.ResourceMapServiceClient) error {
+			stream, err := cli.Watch(ctx, connect_go.NewRequest(req))
+			if err != nil {
+				return err
+			}
+			defer func() {
+				_ = stream.Close()
+			}()
+
+			var received bool
+			for stream.Receive() {
+				received = true
+				if fnErr = fn(stream.Msg()); fnErr != nil {
+					return nil
+				}
+			}
+			if received {
+				// Once connected, reconnect with fresh retry policy.
+				return nil
+			}
+			if err := stream.Err(); err != nil {
+				return err
+			}
+			return errors.New("stream closed")
+		})
+		switch {
+		case fnErr != nil:
+			return fnErr
+		case err != nil:
+			return err
+		case ctx.Err() != nil:
+			return ctx.Err()
+		}
+	}
+}
+
+type (
+	// Broker delivers events to subscribers of Watch.
+	broker struct {
+		_mu       sync.Mutex
+		_subs     map[*subscriber]struct{}
+		_launched *logsv1.ServerLog
+	}
+
+	subscriber struct {
+		_resourceNames []string
+		_mu            sync.Mutex
+		_queue         []*resource_mapv1.WatchResponse
+		_overflowed    bool
+		_notify        chan struct{}
+	}
+)
+
+// Max number of events queued for a subscriber.
+// The subscriber which cannot keep up with events is dropped, instead of growing the queue unboundedly.
+const maxWatchQueue = 1024
+
+var errWatchOverflowed = connect_go.NewError(connect_go.CodeResourceExhausted, errors.New("rsmap: too many events queued for the watcher"))
+
+func newBroker() *broker {
+	return &broker{
+		_subs: map[*subscriber]struct{}{},
+	}
+}
+
+// Subscribe events. The event of current server launch is sent first.
+func (b *broker) subscribe(resourceNames []string) *subscriber {
+	sub := &subscriber{
+		_resourceNames: resourceNames,
+		_notify:        make(chan struct{}, 1),
+	}
+
+	b._mu.Lock()
+	defer b._mu.Unlock()
+	if b._launched != nil {
+		sub.push(&resource_mapv1.WatchResponse{
+			Event: &resource_mapv1.WatchResponse_Server{
+				Server: b._launched,
+			},
+		})
+	}
+	b._subs[sub] = struct{}{}
+	return sub
+}
+
+func (b *broker) unsubscribe(sub *subscriber) {
+	b._mu.Lock()
+	defer b._mu.Unlock()
+	delete(b._subs, sub)
+}
+
+func (b *broker) publish(resp *resource_mapv1.WatchResponse) {
+	if b == nil {
+		return
+	}
+	b._mu.Lock()
+	defer b._mu.Unlock()
+
+	if l := resp.GetServer(); l != nil && l.Event == logsv1.ServerEvent_SERVER_EVENT_LAUNCHED {
+		b._launched = l
+	}
+	for sub := range b._subs {
+		if resp.ResourceName == "" ||
+			len(sub._resourceNames) == 0 ||
+			slices.Contains(sub._resourceNames, resp.ResourceName) {
+			sub.push(resp)
+		}
+	}
+}
+
+func (b *broker) publishServerLog(l *logsv1.ServerLog) {
+	b.publish(&resource_mapv1.WatchResponse{
+		Event: &resource_mapv1.WatchResponse_Server{
+			Server: l,
+		},
+	})
+}
+
+// Enqueue the event without blocking the publisher.
+// When the queue is full, queued events are discarded and the subscriber is marked as overflowed.
+func (s *subscriber) push(resp *resource_mapv1.WatchResponse) {
+	s._mu.Lock()
+	switch {
+	case s._overflowed:
+	case len(s._queue) >= maxWatchQueue:
+		s._queue = nil
+		s._overflowed = true
+	default:
+		s._queue = append(s._queue, resp)
+	}
+	s._mu.Unlock()
+
+	select {
+	case s._notify <- struct{}{}:
+	default:
+	}
+}
+
+func (s *subscriber) take() ([]*resource_mapv1.WatchResponse, bool) {
+	s._mu.Lock()
+	defer s._mu.Unlock()
+	q := s._queue
+	s._queue = nil
+	return q, s._overflowed
+}
+
+// Stream events to the subscriber until ctx is canceled or the server is closing.
+// If the subscriber overflows, this returns errWatchOverflowed.
+func (s *subscriber) stream(ctx context.Context, closing <-chan struct{}, send func(*resource_mapv1.WatchResponse) error) error {
+	flush := func() error {
+		q, overflowed := s.take()
+		if overflowed {
+			return errWatchOverflowed
+		}
+		for _, resp := range q {
+			if err := send(resp); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for {
+		if err := flush(); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-closing:
+			return flush()
+		case <-s._notify:
+		}
+	}
+}
+
+// ResourceRecordStore publishing appended init logs.
+type watchedInitStore struct {
+	logs.ResourceRecordStore[logsv1.InitRecord]
+	_broker *broker
+}
+
+func (s *watchedInitStore) Put(identifiers []string, update func(identifier string, r *logsv1.InitRecord, update bool)) error {
+	var events []*resource_mapv1.WatchResponse
+	err := s.ResourceRecordStore.Put(identifiers, func(identifier string, r *logsv1.InitRecord, u bool) {
+		n := len(r.Logs)
+		update(identifier, r, u)
+		for _, l := range r.Logs[n:] {
+			events = append(events, &resource_mapv1.WatchResponse{
+				ResourceName: identifier,
+				Event: &resource_mapv1.WatchResponse_Init{
+					Init: l,
+				},
+			})
+		}
+	})
+	if err != nil {
+		return err
+	}
+	for _, e := range events {
+		s._broker.publish(e)
+	}
+	return nil
+}
+
+// ResourceRecordStore publishing appended acquisition logs.
+type watchedAcquisitionStore struct {
+	logs.ResourceRecordStore[logsv1.AcquisitionRecord]
+	_broker *broker
+}
+
+func (s *watchedAcquisitionStore) Put(identifiers []string, update func(identifier string, r *logsv1.AcquisitionRecord, update bool)) error {
+	var events []*resource_mapv1.WatchResponse
+	err := s.ResourceRecordStore.Put(identifiers, func(identifier string, r *logsv1.AcquisitionRecord, u bool) {
+		n := len(r.Logs)
+		update(identifier, r, u)
+		for _, l := range r.Logs[n:] {
+			events = append(events, &resource_mapv1.WatchResponse{
+				ResourceName: identifier,
+				Event: &resource_mapv1.WatchResponse_Acquisition{
+					Acquisition: l,
+				},
+			})
+		}
+	})
+	if err != nil {
+		return err
+	}
+	for _, e := range events {
+		s._broker.publish(e)
+	}
+	return nil
+}
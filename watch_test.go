@@ -0,0 +1,148 @@
+package rsmap
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/backoff/v2"
+	"gotest.tools/v3/assert"
+
+	resource_mapv1 "github.com/daichitakahashi/rsmap/internal/proto/resource_map/v1"
+)
+
+func TestMap_Watch(t *testing.T) {
+	t.Parallel()
+
+	m, err := New(t.TempDir(),
+		WithExecutionID("watch"),
+		WithRetryPolicy(backoff.NewConstantPolicy(
+			backoff.WithInterval(time.Millisecond*50),
+			backoff.WithMaxRetries(20),
+		)),
+	)
+	assert.NilError(t, err)
+
+	ctx, cancel := context.WithCancel(background)
+	defer cancel()
+	events, err := m.Watch(ctx, WatchFilter{
+		ResourceNames: []string{"treasure"},
+	})
+	assert.NilError(t, err)
+
+	next := func(t *testing.T) Event {
+		t.Helper()
+
+		select {
+		case e, ok := <-events:
+			assert.Assert(t, ok, "channel is closed")
+			return e
+		case <-time.After(time.Second * 5):
+			t.Fatal("event is not received")
+			return Event{}
+		}
+	}
+
+	// Event of the server launch is received first.
+	e := next(t)
+	assert.Equal(t, e.Kind, EventServerLaunched)
+	assert.Assert(t, e.Addr != "")
+
+	// Events of other resources are not received.
+	_, err = m.Resource(background, "precious")
+	assert.NilError(t, err)
+
+	r, err := m.Resource(background, "treasure", WithInit(func(ctx context.Context) error {
+		return nil
+	}))
+	assert.NilError(t, err)
+	assert.NilError(t, r.Lock(background))
+	assert.NilError(t, r.UnlockAny())
+
+	for _, kind := range []EventKind{
+		EventInitStarted,
+		EventInitCompleted,
+		EventAcquiring,
+		EventAcquired,
+		EventReleased,
+	} {
+		e := next(t)
+		assert.Equal(t, e.Kind, kind, "expected %s, got %s", kind, e.Kind)
+		assert.Equal(t, e.ResourceName, "treasure")
+		assert.Equal(t, e.Operator.String(), r._operator.Context.String())
+	}
+
+	// Event of the server stop is received, and channel is closed.
+	m.Close()
+	e = next(t)
+	assert.Equal(t, e.Kind, EventServerStopped)
+	select {
+	case _, ok := <-events:
+		assert.Assert(t, !ok)
+	case <-time.After(time.Second * 5):
+		t.Fatal("channel is not closed")
+	}
+}
+
+func TestMap_Watch_Kinds(t *testing.T) {
+	t.Parallel()
+
+	m, err := New(t.TempDir(), WithExecutionID("watch"))
+	assert.NilError(t, err)
+	t.Cleanup(m.Close)
+
+	ctx, cancel := context.WithCancel(background)
+	events, err := m.Watch(ctx, WatchFilter{
+		Kinds: []EventKind{EventAcquired},
+	})
+	assert.NilError(t, err)
+
+	r, err := m.Resource(background, "treasure")
+	assert.NilError(t, err)
+	assert.NilError(t, r.RLock(background))
+	assert.NilError(t, r.UnlockAny())
+
+	e := <-events
+	assert.Equal(t, e.Kind, EventAcquired)
+	assert.Equal(t, e.N, int64(1))
+
+	// Channel is closed when ctx is canceled.
+	cancel()
+	for range events {
+	}
+}
+
+func TestMap_Watch_FileLock(t *testing.T) {
+	t.Parallel()
+
+	m, err := New(t.TempDir(), WithExecutionID("watch"), WithFileLock())
+	assert.NilError(t, err)
+	t.Cleanup(m.Close)
+
+	_, err = m.Watch(background, WatchFilter{})
+	assert.ErrorContains(t, err, "not supported in serverless mode")
+}
+
+func TestBroker_Overflow(t *testing.T) {
+	t.Parallel()
+
+	b := newBroker()
+	sub := b.subscribe(nil)
+	defer b.unsubscribe(sub)
+
+	// Subscriber doesn't receive events for a while.
+	for i := 0; i <= maxWatchQueue; i++ {
+		b.publish(&resource_mapv1.WatchResponse{
+			ResourceName: "treasure",
+		})
+	}
+
+	// Queued events are discarded, and the subscriber is dropped.
+	var sent int
+	err := sub.stream(background, nil, func(*resource_mapv1.WatchResponse) error {
+		sent++
+		return nil
+	})
+	assert.ErrorIs(t, err, errWatchOverflowed)
+	assert.Equal(t, sent, 0)
+}